@@ -10,7 +10,14 @@ var browseCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		out := cmd.OutOrStdout()
 		ctx := cmd.Context()
-		return runViewSavedProjects(ctx, out)
+		store, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStore(store)
+		if structuredOutput() {
+			return runListSavedProjects(ctx, store, cmd.ErrOrStderr(), out)
+		}
+		return runViewSavedProjects(ctx, store, out)
 	},
 }
-
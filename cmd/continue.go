@@ -1,18 +1,75 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
+var continueProjectID string
+var continueYes bool
+var continueReadiness bool
+var continueBuilt []string
+var continueStrict bool
+
 var continueCmd = &cobra.Command{
 	Use:   "continue",
 	Short: "Continue an existing project.",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		out := cmd.OutOrStdout()
 		ctx := cmd.Context()
-		return runContinueExisting(ctx, os.Stdin, out)
+		opts := continueOptions{
+			ProjectID:  strings.TrimSpace(continueProjectID),
+			AutoAccept: continueYes,
+			Readiness:  continueReadiness || cmd.Flags().Changed("built"),
+			Strict:     continueStrict,
+		}
+		opts.Headless = opts.ProjectID != "" && (continueYes || structuredOutput())
+		if cmd.Flags().Changed("built") {
+			built, err := builtScopeFromFlag(continueBuilt, os.Stdin)
+			if err != nil {
+				return err
+			}
+			opts.Built = built
+		}
+		if opts.Strict && !opts.Readiness {
+			return withExitCode(exitUsage, fmt.Errorf("continue: --strict requires --readiness or --built"))
+		}
+		if opts.Headless && opts.Readiness && opts.Built == nil {
+			return withExitCode(exitUsage, fmt.Errorf("continue: --readiness requires --built when running non-interactively"))
+		}
+		if structuredOutput() {
+			if opts.ProjectID == "" {
+				return withExitCode(exitUsage, fmt.Errorf("continue: --output %s requires --project", normalizedOutputFormat()))
+			}
+			opts.Docs = out
+			out = cmd.ErrOrStderr()
+		}
+		if continueYes && opts.ProjectID == "" {
+			return withExitCode(exitUsage, fmt.Errorf("continue: --yes requires --project"))
+		}
+		store, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStore(store)
+		return runContinueExisting(ctx, store, os.Stdin, out, opts)
 	},
 }
 
+func continueHeadlessRequested(cmd *cobra.Command) bool {
+	if cmd == nil || cmd.Name() != "continue" {
+		return false
+	}
+	return cmd.Flags().Changed("yes")
+}
+
+func init() {
+	continueCmd.Flags().StringVar(&continueProjectID, "project", "", "ID of the saved project to continue")
+	continueCmd.Flags().BoolVar(&continueYes, "yes", false, "Accept and save the generated evolution without prompting")
+	continueCmd.Flags().BoolVar(&continueReadiness, "readiness", false, "Review readiness against what is built before generating the evolution")
+	continueCmd.Flags().StringSliceVar(&continueBuilt, "built", nil, "What is already built: MVP items or notes, comma separated (- = one per line from stdin)")
+	continueCmd.Flags().BoolVar(&continueStrict, "strict", false, "Stop instead of warning when the readiness verdict is NOT_READY")
+}
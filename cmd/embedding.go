@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quibit/internal/ai"
+	"quibit/internal/config"
+	"quibit/internal/persistence"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/similarity"
+)
+
+// semanticIndex embeds ideas and keeps saved projects' vectors in the store,
+// one set per embedding model. When the configured embedder fails it switches
+// to the local one for the rest of the run.
+type semanticIndex struct {
+	store    persistence.Store
+	embedder similarity.Embedder
+	fallback error
+}
+
+func newSemanticIndex(store persistence.Store) (*semanticIndex, error) {
+	e, err := ai.NewEmbedder(config.LoadAIConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &semanticIndex{store: store, embedder: e}, nil
+}
+
+func (ix *semanticIndex) fallBack(ctx context.Context, err error) bool {
+	if _, local := ix.embedder.(similarity.LocalEmbedder); local || ctx.Err() != nil {
+		return false
+	}
+	ix.fallback = err
+	ix.embedder = similarity.NewLocalEmbedder()
+	return true
+}
+
+// embedBatchSize caps how many texts go to the embedder in one call, so a
+// large library does not become one oversized request.
+const embedBatchSize = 100
+
+// compare embeds text and returns it together with the vectors of rows,
+// embedding and storing the ones not yet embedded with the current model.
+// Texts are embedded in batches; if a batch fails, the vectors already
+// embedded are still stored before switching to the local embedder.
+func (ix *semanticIndex) compare(ctx context.Context, text string, rows []pmodels.Project) ([]float32, map[uuid.UUID][]float32, error) {
+	ids := make([]uuid.UUID, 0, len(rows))
+	for i := range rows {
+		ids = append(ids, rows[i].ID)
+	}
+	for {
+		model := ix.embedder.Model()
+		stored, err := ix.store.ListEmbeddings(ctx, model, ids)
+		if err != nil {
+			return nil, nil, err
+		}
+		vectors := make(map[uuid.UUID][]float32, len(rows))
+		for _, e := range stored {
+			var vec []float32
+			if err := json.Unmarshal([]byte(e.VectorJSON), &vec); err != nil {
+				return nil, nil, fmt.Errorf("parse embedding for %s: %w", e.ProjectID, err)
+			}
+			vectors[e.ProjectID] = vec
+		}
+
+		texts := []string{text}
+		var missing []uuid.UUID
+		for i := range rows {
+			if _, ok := vectors[rows[i].ID]; ok {
+				continue
+			}
+			rowText, err := embeddingTextForRow(rows[i])
+			if err != nil {
+				return nil, nil, err
+			}
+			texts = append(texts, rowText)
+			missing = append(missing, rows[i].ID)
+		}
+
+		vecs, embedErr := ix.embedBatches(ctx, texts)
+		backfill := make([]pmodels.ProjectEmbedding, 0, len(missing))
+		for i := 1; i < len(vecs); i++ {
+			id := missing[i-1]
+			vectors[id] = vecs[i]
+			row, err := newProjectEmbeddingRow(id, model, vecs[i])
+			if err != nil {
+				return nil, nil, err
+			}
+			backfill = append(backfill, row)
+		}
+		if err := ix.store.SaveEmbeddings(ctx, backfill); err != nil {
+			return nil, nil, err
+		}
+		if embedErr != nil {
+			if ix.fallBack(ctx, embedErr) {
+				continue
+			}
+			return nil, nil, embedErr
+		}
+		return vecs[0], vectors, nil
+	}
+}
+
+// embedBatches embeds texts embedBatchSize at a time. On error it returns the
+// vectors of the batches that succeeded, in order.
+func (ix *semanticIndex) embedBatches(ctx context.Context, texts []string) ([][]float32, error) {
+	vecs := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += embedBatchSize {
+		batch := texts[start:min(start+embedBatchSize, len(texts))]
+		out, err := ix.embedder.Embed(ctx, batch)
+		if err != nil {
+			return vecs, err
+		}
+		if len(out) != len(batch) {
+			return vecs, fmt.Errorf("embedder returned %d vectors for %d texts", len(out), len(batch))
+		}
+		vecs = append(vecs, out...)
+	}
+	return vecs, nil
+}
+
+func newProjectEmbeddingRow(projectID uuid.UUID, model string, vec []float32) (pmodels.ProjectEmbedding, error) {
+	b, err := json.Marshal(vec)
+	if err != nil {
+		return pmodels.ProjectEmbedding{}, fmt.Errorf("marshal embedding: %w", err)
+	}
+	return pmodels.ProjectEmbedding{
+		ProjectID:  projectID,
+		Model:      model,
+		Dims:       len(vec),
+		VectorJSON: string(b),
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+func embeddingTextForRow(row pmodels.Project) (string, error) {
+	var idea ai.ProjectIdea
+	if err := json.Unmarshal([]byte(row.RawAIOutput), &idea); err != nil {
+		return "", fmt.Errorf("parse saved raw_ai_output for %s: %w", row.ID, err)
+	}
+	return similarity.EmbeddingText(ai.DomainProject(idea)), nil
+}
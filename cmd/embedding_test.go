@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quibit/internal/persistence"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/similarity"
+)
+
+// batchEmbedder records batch sizes and fails the batch numbered failOn
+// (1-based; 0 never fails).
+type batchEmbedder struct {
+	batches []int
+	failOn  int
+}
+
+func (e *batchEmbedder) Model() string { return "test-embedder" }
+
+func (e *batchEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	e.batches = append(e.batches, len(texts))
+	if len(e.batches) == e.failOn {
+		return nil, errors.New("embedder unavailable")
+	}
+	out := make([][]float32, len(texts))
+	for i := range out {
+		out[i] = []float32{1, float32(i)}
+	}
+	return out, nil
+}
+
+func embeddingTestRows(t *testing.T, n int) []pmodels.Project {
+	t.Helper()
+	raw, err := os.ReadFile("../internal/ai/testdata/project_idea.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := make([]pmodels.Project, n)
+	for i := range rows {
+		rows[i] = pmodels.Project{ID: uuid.New(), RawAIOutput: string(raw), CreatedAt: time.Now()}
+	}
+	return rows
+}
+
+func storedEmbeddings(t *testing.T, store persistence.Store, model string, rows []pmodels.Project) int {
+	t.Helper()
+	ids := make([]uuid.UUID, len(rows))
+	for i := range rows {
+		ids[i] = rows[i].ID
+	}
+	stored, err := store.ListEmbeddings(context.Background(), model, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(stored)
+}
+
+func TestSemanticIndexEmbedsInBatches(t *testing.T) {
+	store := persistence.NewMemoryStore()
+	rows := embeddingTestRows(t, 150)
+	e := &batchEmbedder{}
+	ix := &semanticIndex{store: store, embedder: e}
+
+	vec, vectors, err := ix.compare(context.Background(), "new idea", rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(e.batches) != 2 || e.batches[0] != embedBatchSize || e.batches[1] != 51 {
+		t.Errorf("batches = %v, want [%d 51]", e.batches, embedBatchSize)
+	}
+	if vec == nil || len(vectors) != len(rows) || ix.fallback != nil {
+		t.Errorf("got %d vectors (fallback %v), want all %d from the configured embedder", len(vectors), ix.fallback, len(rows))
+	}
+	if got := storedEmbeddings(t, store, e.Model(), rows); got != len(rows) {
+		t.Errorf("stored %d embeddings, want %d", got, len(rows))
+	}
+}
+
+func TestSemanticIndexFallsBackOnlyWhenABatchFails(t *testing.T) {
+	store := persistence.NewMemoryStore()
+	rows := embeddingTestRows(t, 150)
+	e := &batchEmbedder{failOn: 2}
+	ix := &semanticIndex{store: store, embedder: e}
+
+	_, vectors, err := ix.compare(context.Background(), "new idea", rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ix.fallback == nil {
+		t.Fatal("a failed batch did not switch to the local embedder")
+	}
+	if _, local := ix.embedder.(similarity.LocalEmbedder); !local {
+		t.Errorf("embedder = %T, want the local embedder", ix.embedder)
+	}
+	if len(vectors) != len(rows) {
+		t.Errorf("got %d vectors, want %d from the local embedder", len(vectors), len(rows))
+	}
+	// The first batch held the new idea and 99 saved projects.
+	if got := storedEmbeddings(t, store, e.Model(), rows); got != embedBatchSize-1 {
+		t.Errorf("stored %d embeddings from the first batch, want %d", got, embedBatchSize-1)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"quibit/internal/ai"
+	"quibit/internal/model"
+	"quibit/internal/persistence"
+	"quibit/internal/project"
+	"quibit/internal/tui"
+)
+
+// ensemblePick is the winning ensemble candidate with the similarity result
+// computed while ranking, so the generate loop does not score it twice.
+type ensemblePick struct {
+	Winner int
+	Meta   ai.AIResult
+	Action project.SimilarityDecision
+	Score  float64
+	Match  *similarityMatch
+	Scores []float64
+}
+
+// pickEnsembleCandidate scores every decoded candidate against the saved
+// library and ranks them with ai.RankEnsemble. Candidates that failed keep a
+// similarity of 1 so they can never win on distance.
+func pickEnsembleCandidate(ctx context.Context, store persistence.Store, out io.Writer, input model.ProjectInput, cands []ai.EnsembleCandidate) (ensemblePick, error) {
+	type scored struct {
+		action project.SimilarityDecision
+		match  *similarityMatch
+	}
+	results := make([]scored, len(cands))
+	sims := make([]float64, len(cands))
+	for i, c := range cands {
+		sims[i] = 1
+		if c.Err != nil {
+			continue
+		}
+		action, score, match, err := evaluateSimilarity(ctx, store, out, c.Idea, input)
+		if err != nil {
+			return ensemblePick{}, err
+		}
+		results[i] = scored{action: action, match: match}
+		sims[i] = score
+	}
+	winner := ai.RankEnsemble(cands, sims)[0]
+	return ensemblePick{
+		Winner: winner,
+		Meta:   ai.EnsembleWinnerMeta(cands, winner),
+		Action: results[winner].action,
+		Score:  sims[winner],
+		Match:  results[winner].match,
+		Scores: sims,
+	}, nil
+}
+
+func printEnsembleSummary(out io.Writer, cands []ai.EnsembleCandidate, pick ensemblePick) {
+	tui.Heading(out, "Ensemble")
+	for i, c := range cands {
+		marker := "  "
+		if i == pick.Winner {
+			marker = "▸ "
+		}
+		if c.Err != nil {
+			fmt.Fprintf(out, "%s%-12s FAILED  %s\n", marker, c.Provider, c.Summary())
+			continue
+		}
+		fmt.Fprintf(out, "%s%-12s %-10s similarity %.2f · %dms\n", marker, c.Provider, c.Verdict(), pick.Scores[i], c.Meta.LatencyMS)
+	}
+	if w := cands[pick.Winner]; w.Err == nil {
+		tui.Hint(out, "Kept "+w.Provider+"'s idea.")
+	}
+}
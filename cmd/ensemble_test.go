@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"quibit/internal/ai"
+	"quibit/internal/model"
+)
+
+var ensembleInput = model.ProjectInput{
+	AppType:    "cli",
+	Complexity: "intermediate",
+	TechStack:  []string{},
+	Database:   []string{"SQLite"},
+	Goal:       "Portfolio",
+	Timeframe:  "2-4 weeks",
+}
+
+var ensembleArgs = []string{"generate", "--ensemble", "--app-type", "cli", "--complexity", "intermediate", "--db", "SQLite",
+	"--goal", "Portfolio", "--timeframe", "2-4 weeks", "--yes", "--output", "json"}
+
+func TestHeadlessEnsembleRegeneratesAfterQualityGateFailure(t *testing.T) {
+	store := useMemoryStore(t)
+	first := ai.BuildProjectIdeaPrompt(ensembleInput)
+	useReplayFixtures(t, map[string]string{first: "../internal/ai/testdata/project_idea_generic.json"})
+	cands, err := ai.GenerateProjectIdeaEnsemble(context.Background(), ensembleInput, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cands) != 1 || cands[0].Err != nil || cands[0].Accepted() {
+		t.Fatalf("generic fixture must decode and fail the gate: %+v", cands)
+	}
+	retry := ai.BuildProjectIdeaPivotPrompt(ensembleInput, ai.RetryQualityTooGeneric, cands[0].RetryStrategy(1),
+		ai.AvoidList{Rejected: []ai.ProjectIdea{cands[0].Idea}})
+	useReplayFixtures(t, map[string]string{
+		first: "../internal/ai/testdata/project_idea_generic.json",
+		retry: "../internal/ai/testdata/project_idea.json",
+	})
+
+	var generated projectDocument
+	out := runCommand(t, ensembleArgs...)
+	if err := json.Unmarshal(out, &generated); err != nil {
+		t.Fatalf("decode generate output: %v\n%s", err, out)
+	}
+	if !generated.Saved || generated.Idea.Project.Name != "Ledgerline Replay" {
+		t.Fatalf("generate saved=%v name=%q, want the regenerated idea saved", generated.Saved, generated.Idea.Project.Name)
+	}
+	rows, err := store.ListRecentProjects(context.Background(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("store has %d projects, want 1", len(rows))
+	}
+}
+
+func TestHeadlessEnsembleUndecodableAnswersAreProviderFailure(t *testing.T) {
+	useMemoryStore(t)
+	dir := t.TempDir()
+	fixture := filepath.Join(dir, "garbage.txt")
+	if err := os.WriteFile(fixture, []byte("the model wandered off and wrote prose"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	useReplayFixtures(t, map[string]string{ai.BuildProjectIdeaPrompt(ensembleInput): fixture})
+
+	_, err := executeCommand(t, ensembleArgs...)
+	if err == nil {
+		t.Fatal("generate succeeded without a decodable answer")
+	}
+	if got := exitCodeFor(err); got != exitProviderFailure {
+		t.Errorf("exit code = %d, want %d (%v)", got, exitProviderFailure, err)
+	}
+}
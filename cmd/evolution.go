@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quibit/internal/ai"
+	"quibit/internal/persistence"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/project"
+	"quibit/internal/tui"
+)
+
+func newProjectDNARow(projectID uuid.UUID, dna project.ProjectDNA) (pmodels.ProjectDNA, error) {
+	dna = dna.Canonical()
+	stackJSON, err := json.Marshal(dna.CoreTechStack)
+	if err != nil {
+		return pmodels.ProjectDNA{}, fmt.Errorf("marshal dna tech stack: %w", err)
+	}
+	return pmodels.ProjectDNA{
+		ProjectID:          projectID,
+		AppType:            dna.AppType,
+		PrimaryDomain:      dna.PrimaryDomain,
+		CoreTechStackJSON:  string(stackJSON),
+		ArchitecturalStyle: dna.ArchitecturalStyle,
+		ComplexityLevel:    dna.ComplexityLevel,
+		Fingerprint:        dna.FingerprintHash(),
+		CreatedAt:          time.Now(),
+	}, nil
+}
+
+func projectDNAFromRow(row pmodels.ProjectDNA) (project.ProjectDNA, error) {
+	var stack []string
+	if err := json.Unmarshal([]byte(row.CoreTechStackJSON), &stack); err != nil {
+		return project.ProjectDNA{}, fmt.Errorf("parse dna tech stack: %w", err)
+	}
+	return project.ProjectDNA{
+		AppType:            row.AppType,
+		PrimaryDomain:      row.PrimaryDomain,
+		CoreTechStack:      stack,
+		ArchitecturalStyle: row.ArchitecturalStyle,
+		ComplexityLevel:    row.ComplexityLevel,
+	}, nil
+}
+
+// ensureProjectDNA loads the stored DNA, extracting and storing it first for
+// projects saved before DNA was recorded.
+func ensureProjectDNA(ctx context.Context, store persistence.Store, selected *pmodels.Project) (project.ProjectDNA, error) {
+	row, err := store.GetProjectDNA(ctx, selected.ID)
+	if err == nil {
+		dna, err := projectDNAFromRow(row)
+		if err != nil {
+			return project.ProjectDNA{}, err
+		}
+		return dna, nil
+	}
+	if !errors.Is(err, persistence.ErrNotFound) {
+		return project.ProjectDNA{}, err
+	}
+
+	var idea ai.ProjectIdea
+	if err := json.Unmarshal([]byte(selected.RawAIOutput), &idea); err != nil {
+		return project.ProjectDNA{}, fmt.Errorf("parse saved raw_ai_output: %w", err)
+	}
+	dna := ai.ExtractProjectDNA(idea, selected.AppType, selected.ProjectKind)
+	row, err = newProjectDNARow(selected.ID, dna)
+	if err != nil {
+		return project.ProjectDNA{}, err
+	}
+	if err := store.SaveProjectDNA(ctx, row); err != nil {
+		return project.ProjectDNA{}, err
+	}
+	return dna, nil
+}
+
+type evolutionChain struct {
+	Base       []string
+	Evolutions []pmodels.ProjectEvolution
+	Phases     []ai.EvolutionPhase
+}
+
+// Scope is the current scope the next phase builds on.
+func (c evolutionChain) Scope() []string {
+	if n := len(c.Phases); n > 0 {
+		return c.Phases[n-1].Scope
+	}
+	return c.Base
+}
+
+func loadEvolutionChain(ctx context.Context, store persistence.Store, selected *pmodels.Project) (evolutionChain, error) {
+	base, err := parseStringArray(selected.MVPScopeJSON)
+	if err != nil {
+		return evolutionChain{}, fmt.Errorf("parse mvp scope: %w", err)
+	}
+	rows, err := store.ListEvolutions(ctx, selected.ID)
+	if err != nil {
+		return evolutionChain{}, err
+	}
+	versions, err := store.ListScopeVersions(ctx, selected.ID)
+	if err != nil {
+		return evolutionChain{}, err
+	}
+	scopes := make(map[uuid.UUID]*ai.ScopeVersion, len(versions))
+	for i := range versions {
+		if versions[i].EvolutionID == nil {
+			continue
+		}
+		scope, err := decodeScopeVersion(versions[i])
+		if err != nil {
+			return evolutionChain{}, err
+		}
+		scopes[*versions[i].EvolutionID] = &scope
+	}
+	saved := make([]ai.SavedEvolution, 0, len(rows))
+	for i := range rows {
+		evo, err := ai.DecodeSavedEvolution(rows[i].Contract, rows[i].RawAIOutput)
+		if err != nil {
+			return evolutionChain{}, fmt.Errorf("parse saved evolution %s: %w", rows[i].ID, err)
+		}
+		if evo.NextPhase != nil {
+			evo.Scope = scopes[rows[i].ID]
+		}
+		saved = append(saved, evo)
+	}
+	return evolutionChain{
+		Base:       base,
+		Evolutions: rows,
+		Phases:     ai.BuildEvolutionChain(base, saved),
+	}, nil
+}
+
+func decodeScopeVersion(row pmodels.ProjectScopeVersion) (ai.ScopeVersion, error) {
+	mustHave, err := parseStringArray(row.MustHaveJSON)
+	if err != nil {
+		return ai.ScopeVersion{}, fmt.Errorf("parse scope version %d: %w", row.Version, err)
+	}
+	outOfScope, err := parseStringArray(row.OutOfScopeJSON)
+	if err != nil {
+		return ai.ScopeVersion{}, fmt.Errorf("parse scope version %d: %w", row.Version, err)
+	}
+	return ai.ScopeVersion{MustHave: mustHave, OutOfScope: outOfScope}, nil
+}
+
+func saveNextPhaseEvolution(ctx context.Context, store persistence.Store, projectID uuid.UUID, evo ai.NextPhaseEvolution, rawJSON string, meta ai.AIResult) (uuid.UUID, int, error) {
+	mustHaveJSON, err := json.Marshal(evo.UpdatedMVPScope.MustHave)
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("continue: marshal updated scope: %w", err)
+	}
+	outOfScopeJSON, err := json.Marshal(evo.UpdatedMVPScope.OutOfScope)
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("continue: marshal updated scope: %w", err)
+	}
+
+	row := newProjectEvolutionRow(projectID, ai.EvolutionContractNextPhase, rawJSON, meta)
+	scope, err := store.SaveEvolutionPhase(ctx, row, pmodels.ProjectScopeVersion{
+		ID:             uuid.New(),
+		MustHaveJSON:   string(mustHaveJSON),
+		OutOfScopeJSON: string(outOfScopeJSON),
+		CreatedAt:      row.CreatedAt,
+	})
+	if err != nil {
+		return uuid.Nil, 0, fmt.Errorf("continue: %w", err)
+	}
+	return row.ID, scope.Version, nil
+}
+
+func newProjectEvolutionRow(projectID uuid.UUID, contract string, rawJSON string, meta ai.AIResult) pmodels.ProjectEvolution {
+	providerUsed := strings.TrimSpace(meta.ProviderUsed)
+	if providerUsed == "" {
+		providerUsed = "gemini"
+	}
+	var providerErrPtr *string
+	if strings.TrimSpace(meta.ProviderError) != "" {
+		v := meta.ProviderError
+		providerErrPtr = &v
+	}
+	return pmodels.ProjectEvolution{
+		ID:            uuid.New(),
+		ProjectID:     projectID,
+		Contract:      contract,
+		RawAIOutput:   rawJSON,
+		ProviderUsed:  providerUsed,
+		FallbackUsed:  meta.FallbackUsed,
+		ProviderError: providerErrPtr,
+		LatencyMS:     meta.LatencyMS,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func printSavedEvolution(out io.Writer, row pmodels.ProjectEvolution) error {
+	evo, err := ai.DecodeSavedEvolution(row.Contract, row.RawAIOutput)
+	if err != nil {
+		return fmt.Errorf("view: parse saved evolution: %w", err)
+	}
+	if evo.NextPhase != nil {
+		printNextPhaseEvolution(out, *evo.NextPhase)
+		return nil
+	}
+	printEvolution(out, *evo.Project)
+	return nil
+}
+
+func printNextPhaseEvolution(out io.Writer, evo ai.NextPhaseEvolution) {
+	tui.Heading(out, "Next Phase Goal")
+	fmt.Fprintln(out, evo.EvolutionGoal)
+
+	tui.Heading(out, "Architectural Changes")
+	for _, item := range evo.ArchitecturalChanges {
+		fmt.Fprintf(out, "- %s\n", item)
+	}
+
+	tui.Heading(out, "New Engineering Concerns")
+	for _, item := range evo.NewEngineeringConcerns {
+		fmt.Fprintf(out, "- %s\n", item)
+	}
+
+	tui.Heading(out, "Updated MVP Scope")
+	for _, item := range evo.UpdatedMVPScope.MustHave {
+		fmt.Fprintf(out, "- %s\n", item)
+	}
+	if len(evo.UpdatedMVPScope.OutOfScope) > 0 {
+		tui.Heading(out, "Out of Scope")
+		for _, item := range evo.UpdatedMVPScope.OutOfScope {
+			fmt.Fprintf(out, "- %s\n", item)
+		}
+	}
+
+	tui.Heading(out, "Skills and Concepts Learned")
+	for _, item := range evo.SkillsAndConceptsLearned {
+		fmt.Fprintf(out, "- %s\n", item)
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+
+	"quibit/internal/ai"
+)
+
+const (
+	exitFailure           = 1
+	exitUsage             = 2
+	exitSimilarityBlocked = 3
+	exitQualityGate       = 4
+	exitProviderFailure   = 5
+	exitNotReady          = 6
+	exitInterrupted       = 130
+)
+
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e == nil || e.err == nil {
+		return ""
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.err
+}
+
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+func exitCodeFor(err error) int {
+	var ee *exitError
+	if errors.As(err, &ee) && ee.code > 0 {
+		return ee.code
+	}
+	return exitFailure
+}
+
+func classifyGenerateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var ee *exitError
+	if errors.As(err, &ee) {
+		return err
+	}
+	switch {
+	case errors.Is(err, ai.ErrQualityGateFailed):
+		return withExitCode(exitQualityGate, err)
+	case errors.Is(err, ai.ErrProvidersFailed):
+		return withExitCode(exitProviderFailure, err)
+	default:
+		return err
+	}
+}
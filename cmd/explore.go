@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"quibit/internal/ai"
+	"quibit/internal/model"
+	"quibit/internal/persistence"
+	"quibit/internal/project"
+	"quibit/internal/tui"
+	tuiinput "quibit/internal/tui/input"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sys/unix"
+)
+
+const maxExploreCandidates = 6
+
+var (
+	exploreAppType    string
+	exploreDomain     string
+	exploreProblem    string
+	exploreAxis       string
+	exploreComplexity string
+	exploreStack      []string
+	exploreGoal       string
+	exploreTimeframe  string
+	exploreCount      int
+	exploreSeed       string
+	explorePick       int
+)
+
+var exploreCmd = &cobra.Command{
+	Use:   "explore",
+	Short: "Expand one base spec into several candidate projects and pick one to save.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if exploreCount < 1 || exploreCount > maxExploreCandidates {
+			return withExitCode(exitUsage, fmt.Errorf("explore: --count must be between 1 and %d", maxExploreCandidates))
+		}
+		if explorePick < 0 || explorePick > exploreCount {
+			return withExitCode(exitUsage, fmt.Errorf("explore: --pick must be between 1 and --count"))
+		}
+
+		out := cmd.OutOrStdout()
+		var docs io.Writer
+		if structuredOutput() {
+			docs = out
+			out = cmd.ErrOrStderr()
+		}
+		headless := docs != nil || explorePick > 0 || !stdinIsTerminal()
+
+		base := project.IdeaSpec{
+			AppType:           exploreAppType,
+			DomainFocus:       exploreDomain,
+			CoreProblem:       exploreProblem,
+			ArchitecturalAxis: exploreAxis,
+			ComplexityLevel:   exploreComplexity,
+		}
+		if headless {
+			if strings.TrimSpace(base.DomainFocus) == "" || strings.TrimSpace(base.CoreProblem) == "" {
+				return withExitCode(exitUsage, fmt.Errorf("explore: --domain and --problem are required in non-interactive mode"))
+			}
+			if strings.TrimSpace(base.AppType) == "" {
+				base.AppType = "web"
+			}
+			if strings.TrimSpace(base.ComplexityLevel) == "" {
+				base.ComplexityLevel = tuiinput.ComplexityPrompt.Default.Value
+			}
+		} else {
+			var err error
+			base, err = tuiinput.CollectIdeaSpec(os.Stdin, out, base)
+			if err != nil {
+				return err
+			}
+		}
+
+		ctx := cmd.Context()
+		store, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStore(store)
+
+		return runExplore(ctx, store, out, docs, base, headless)
+	},
+}
+
+type exploreResult struct {
+	Candidate ai.ExploreCandidate
+	Input     model.ProjectInput
+	Score     float64
+	Decision  project.SimilarityDecision
+	Match     *similarityMatch
+}
+
+func runExplore(ctx context.Context, store persistence.Store, out io.Writer, docs io.Writer, base project.IdeaSpec, headless bool) error {
+	seed := strings.TrimSpace(exploreSeed)
+	if seed == "" {
+		seed = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	specs := project.DefaultIdeaVariationEngine().GenerateCandidates(base, seed, exploreCount)
+
+	labels := make([]string, len(specs))
+	for i, spec := range specs {
+		labels[i] = fmt.Sprintf("Candidate %d · %s", i+1, spec.ArchitecturalAxis)
+	}
+	progress := tui.StartProgress(ctx, out, labels)
+	outcomes, err := ai.ExpandIdeaSpecsConcurrently(ctx, specs, func(ev ai.CandidateEvent) {
+		switch ev.State {
+		case ai.CandidateRunning:
+			progress.Set(ev.Index, tui.TaskRunning, "expanding")
+		case ai.CandidateAccepted:
+			progress.Set(ev.Index, tui.TaskDone, "ready")
+		case ai.CandidateRejected:
+			progress.Set(ev.Index, tui.TaskFailed, rejectionSummary(ev.Err))
+		}
+	})
+	progress.Stop()
+	if err != nil {
+		return classifyGenerateError(fmt.Errorf("explore: %w", err))
+	}
+
+	var results []exploreResult
+	for i := range outcomes {
+		if outcomes[i].Err != nil {
+			continue
+		}
+		cand := outcomes[i].Candidate
+		input := exploreProjectInput(cand.Spec, cand.Idea)
+		decision, score, match, err := evaluateSimilarity(ctx, store, out, cand.Idea, input)
+		if err != nil {
+			return err
+		}
+		if decision == project.SimilarityBlock {
+			tui.Status(out, fmt.Sprintf("Candidate %d dropped: similarity %.2f to a saved project", i+1, score))
+			continue
+		}
+		results = append(results, exploreResult{Candidate: cand, Input: input, Score: score, Decision: decision, Match: match})
+	}
+	if len(results) == 0 {
+		return withExitCode(exitQualityGate, fmt.Errorf("explore: no candidate passed the quality and similarity checks"))
+	}
+
+	if docs == nil {
+		printExploreComparison(out, results)
+	}
+
+	if headless {
+		var picked *exploreResult
+		var pickedID string
+		if explorePick > 0 {
+			if explorePick > len(results) {
+				return withExitCode(exitUsage, fmt.Errorf("explore: --pick %d but only %d candidates were expanded", explorePick, len(results)))
+			}
+			picked = &results[explorePick-1]
+			id, err := saveExploreResult(ctx, store, out, *picked)
+			if err != nil {
+				return err
+			}
+			pickedID = id
+		}
+		if docs != nil {
+			result := make([]projectDocument, 0, len(results))
+			for i := range results {
+				doc := exploreDocument(results[i])
+				if picked == &results[i] {
+					doc.ProjectID = pickedID
+					doc.Saved = true
+				}
+				result = append(result, doc)
+			}
+			return emitDocuments(docs, result)
+		}
+		if picked == nil {
+			tui.BlankLine(out)
+			tui.Hint(out, "Not saved. Pass --pick N to save a candidate.")
+		}
+		return nil
+	}
+
+	options := make([]tui.Option, 0, len(results)+1)
+	for i := range results {
+		options = append(options, tui.Option{
+			ID:    strconv.Itoa(i),
+			Label: fmt.Sprintf("Save #%d — %s", i+1, results[i].Candidate.Idea.Project.Name),
+		})
+	}
+	options = append(options, tui.Option{ID: "back", Label: "Discard all"})
+	selection, err := tui.SelectOption(os.Stdin, out, "Pick a candidate to save.", options)
+	if err != nil {
+		return err
+	}
+	if selection.ID == "back" {
+		return nil
+	}
+	idx, err := strconv.Atoi(selection.ID)
+	if err != nil || idx < 0 || idx >= len(results) {
+		return fmt.Errorf("explore: invalid selection")
+	}
+	printIdea(out, results[idx].Candidate.Idea, results[idx].Input)
+	_, err = saveExploreResult(ctx, store, out, results[idx])
+	return err
+}
+
+func rejectionSummary(err error) string {
+	if errors.Is(err, ai.ErrQualityGateFailed) {
+		return "rejected by quality gate"
+	}
+	if errors.Is(err, context.Canceled) {
+		return "cancelled"
+	}
+	return err.Error()
+}
+
+// exploreProjectInput is what a saved candidate records as its input: the
+// spec it was expanded from, plus goal, timeframe and stack from the flags or,
+// when those are unset, the candidate itself and the generate defaults.
+func exploreProjectInput(spec project.IdeaSpec, idea ai.ProjectIdea) model.ProjectInput {
+	stack := cleanFlagList(exploreStack)
+	if len(stack) == 0 {
+		stack = flattenTechStack(idea.Project.TechStack)
+	}
+	timeframe := flagOrDefault(idea.Project.Duration.Range, tuiinput.EstimatedTimeframePrompt.Default.Value)
+	return model.ProjectInput{
+		AppType:     spec.AppType,
+		ProjectKind: spec.DomainFocus,
+		Complexity:  spec.ComplexityLevel,
+		TechStack:   stack,
+		Goal:        flagOrDefault(exploreGoal, tuiinput.ProjectGoalPrompt.Default.Value),
+		Timeframe:   flagOrDefault(exploreTimeframe, timeframe),
+	}
+}
+
+func saveExploreResult(ctx context.Context, store persistence.Store, out io.Writer, r exploreResult) (string, error) {
+	if r.Decision == project.SimilarityBlock {
+		return "", withExitCode(exitSimilarityBlocked, fmt.Errorf("explore: blocked: similarity %.2f is too high", r.Score))
+	}
+	saveSpin := tui.StartSpinner(ctx, out, "Saving project")
+	id, err := saveGeneratedProject(ctx, store, r.Input, r.Candidate.Idea, r.Candidate.RawJSON, r.Candidate.Meta, nil, r.Score, r.Match)
+	saveSpin.Stop()
+	if errors.Is(err, persistence.ErrDuplicateDNA) {
+		return "", withExitCode(exitSimilarityBlocked, fmt.Errorf("explore: candidate duplicates a saved project"))
+	}
+	if err != nil {
+		return "", err
+	}
+	tui.BlankLine(out)
+	tui.Done(out, "Saved "+id.String())
+	return id.String(), nil
+}
+
+func exploreDocument(r exploreResult) projectDocument {
+	return projectDocument{
+		Type:       "candidate",
+		Input:      newInputDocument(r.Input),
+		Idea:       r.Candidate.Idea,
+		AI:         newAIMetaDocument(r.Candidate.Meta, nil),
+		Similarity: &similarityDocument{Score: r.Score, Decision: similarityDecisionName(r.Decision), Breakdown: newSimilarityBreakdownDocument(r.Match)},
+		Quality:    newQualityScoresDocument(r.Candidate.Meta.Quality),
+	}
+}
+
+func printExploreComparison(out io.Writer, results []exploreResult) {
+	titles := make([]string, len(results))
+	row := func(label string, value func(r exploreResult) string) tui.CompareRow {
+		values := make([]string, len(results))
+		for i := range results {
+			values[i] = value(results[i])
+		}
+		return tui.CompareRow{Label: label, Values: values}
+	}
+	bullets := func(items []string) string {
+		lines := make([]string, 0, len(items))
+		for _, v := range items {
+			if v = strings.TrimSpace(v); v != "" {
+				lines = append(lines, "• "+v)
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+	for i := range results {
+		titles[i] = fmt.Sprintf("#%d %s", i+1, results[i].Candidate.Idea.Project.Name)
+	}
+
+	tui.Heading(out, "Candidates")
+	tui.CompareColumns(out, titles, []tui.CompareRow{
+		row("Tagline", func(r exploreResult) string { return r.Candidate.Idea.Project.Tagline }),
+		row("Architecture", func(r exploreResult) string { return r.Candidate.Spec.ArchitecturalAxis }),
+		row("Complexity", func(r exploreResult) string { return r.Candidate.Spec.ComplexityLevel }),
+		row("Stack", func(r exploreResult) string {
+			return strings.Join(flattenTechStack(r.Candidate.Idea.Project.TechStack), ", ")
+		}),
+		row("MVP goal", func(r exploreResult) string { return r.Candidate.Idea.Project.MVP.Goal }),
+		row("Must have", func(r exploreResult) string { return bullets(r.Candidate.Idea.Project.MVP.MustHave) }),
+		row("Similarity", func(r exploreResult) string {
+			return fmt.Sprintf("%.2f (%s)", r.Score, similarityDecisionName(r.Decision))
+		}),
+	})
+}
+
+func stdinIsTerminal() bool {
+	_, err := unix.IoctlGetTermios(int(os.Stdin.Fd()), unix.TCGETS)
+	return err == nil
+}
+
+func init() {
+	f := exploreCmd.Flags()
+	f.StringVar(&exploreAppType, "app-type", "", "Application type of the base spec (web, cli, mobile, ...)")
+	f.StringVar(&exploreDomain, "domain", "", "Domain focus of the base spec")
+	f.StringVar(&exploreProblem, "problem", "", "Core problem every candidate must solve")
+	f.StringVar(&exploreAxis, "axis", "", "Optional architectural axis to start from")
+	f.StringVar(&exploreComplexity, "complexity", "", "Complexity level (beginner|intermediate|advanced)")
+	f.StringSliceVar(&exploreStack, "stack", nil, "Tech stack to record for the saved candidate (default: the candidate's own stack)")
+	f.StringVar(&exploreGoal, "goal", "", "Project goal to record for the saved candidate")
+	f.StringVar(&exploreTimeframe, "timeframe", "", "Timeframe to record for the saved candidate (default: the candidate's estimate)")
+	f.IntVarP(&exploreCount, "count", "n", 3, "Number of candidates to generate")
+	f.StringVar(&exploreSeed, "seed", "", "Variation seed (same seed and spec give the same candidates)")
+	f.IntVar(&explorePick, "pick", 0, "Save candidate N without prompting")
+}
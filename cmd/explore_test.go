@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"quibit/internal/ai"
+	"quibit/internal/project"
+	tuiinput "quibit/internal/tui/input"
+)
+
+func TestExploreProjectInput(t *testing.T) {
+	spec := project.IdeaSpec{AppType: "web", DomainFocus: "logistics", ComplexityLevel: "advanced"}
+	var idea ai.ProjectIdea
+	idea.Project.TechStack = ai.ProjectTechStack{Backend: "Go", Database: "PostgreSQL"}
+	idea.Project.Duration.Range = "1-3 months"
+
+	in := exploreProjectInput(spec, idea)
+	if in.Goal != tuiinput.ProjectGoalPrompt.Default.Value {
+		t.Errorf("goal = %q, want the generate default", in.Goal)
+	}
+	if in.Timeframe != "1-3 months" {
+		t.Errorf("timeframe = %q, want the candidate's estimate", in.Timeframe)
+	}
+	if want := []string{"Go", "PostgreSQL"}; !reflect.DeepEqual(in.TechStack, want) {
+		t.Errorf("stack = %v, want %v", in.TechStack, want)
+	}
+
+	exploreGoal, exploreTimeframe, exploreStack = "open source tool", "2-4 weeks", []string{"Rust", " "}
+	t.Cleanup(func() { exploreGoal, exploreTimeframe, exploreStack = "", "", nil })
+	in = exploreProjectInput(spec, idea)
+	if in.Goal != "open source tool" || in.Timeframe != "2-4 weeks" || !reflect.DeepEqual(in.TechStack, []string{"Rust"}) {
+		t.Errorf("flags were not used: goal=%q timeframe=%q stack=%v", in.Goal, in.Timeframe, in.TechStack)
+	}
+	if in.AppType != "web" || in.ProjectKind != "logistics" || in.Complexity != "advanced" {
+		t.Errorf("spec fields were not kept: %+v", in)
+	}
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"quibit/internal/ai"
+	"quibit/internal/config"
+	"quibit/internal/export"
+	"quibit/internal/persistence"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/tui"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportAll         bool
+	exportFormat      string
+	exportOutDir      string
+	exportTemplateDir string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [project-id]",
+	Short: "Export saved projects as Markdown, JSON, YAML or HTML briefs.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if exportAll == (len(args) == 1) {
+			return withExitCode(exitUsage, fmt.Errorf("export: pass a project id or --all"))
+		}
+		templateDir := strings.TrimSpace(exportTemplateDir)
+		if templateDir == "" {
+			templateDir = config.ExportTemplateDir()
+		}
+		exporter, err := export.NewExporter(exportFormat, templateDir)
+		if err != nil {
+			return withExitCode(exitUsage, err)
+		}
+
+		ctx := cmd.Context()
+		out := cmd.OutOrStdout()
+		store, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStore(store)
+
+		var rows []pmodels.Project
+		if exportAll {
+			rows, err = store.ListRecentProjects(ctx, 0)
+			if err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+		} else {
+			projectID, err := uuid.Parse(strings.TrimSpace(args[0]))
+			if err != nil {
+				return withExitCode(exitUsage, fmt.Errorf("export: invalid project id %q", args[0]))
+			}
+			row, err := store.GetProject(ctx, projectID)
+			if errors.Is(err, persistence.ErrNotFound) {
+				return fmt.Errorf("export: project %s not found", projectID)
+			}
+			if err != nil {
+				return fmt.Errorf("export: %w", err)
+			}
+			rows = append(rows, row)
+		}
+		if len(rows) == 0 {
+			tui.Context(out, "No saved projects to export.")
+			return nil
+		}
+
+		for i := range rows {
+			doc, err := buildExportDocument(ctx, store, rows[i])
+			if err != nil {
+				return err
+			}
+			path, err := exporter.WriteFile(exportOutDir, doc)
+			if err != nil {
+				return err
+			}
+			tui.Done(out, "Exported "+path)
+		}
+		return nil
+	},
+}
+
+func buildExportDocument(ctx context.Context, store persistence.Store, row pmodels.Project) (export.Document, error) {
+	var idea ai.ProjectIdea
+	if err := json.Unmarshal([]byte(row.RawAIOutput), &idea); err != nil {
+		return export.Document{}, fmt.Errorf("export: parse saved raw_ai_output for %s: %w", row.ID, err)
+	}
+	evolutions, err := loadProjectEvolutions(ctx, store, row.ID)
+	if err != nil {
+		return export.Document{}, err
+	}
+
+	doc := export.Document{
+		ProjectID: row.ID.String(),
+		CreatedAt: row.CreatedAt,
+		AppType:   row.AppType,
+		Goal:      row.Goal,
+		Provider:  row.ProviderUsed,
+		Idea:      idea,
+	}
+	for i := range evolutions {
+		evo, err := ai.DecodeSavedEvolution(evolutions[i].Contract, evolutions[i].RawAIOutput)
+		if err != nil {
+			return export.Document{}, fmt.Errorf("export: parse saved evolution %s: %w", evolutions[i].ID, err)
+		}
+		doc.Evolutions = append(doc.Evolutions, export.Evolution{
+			EvolutionID: evolutions[i].ID.String(),
+			CreatedAt:   evolutions[i].CreatedAt,
+			Evolution:   evo.Project,
+			NextPhase:   evo.NextPhase,
+		})
+	}
+	return doc, nil
+}
+
+func init() {
+	exportCmd.Flags().BoolVar(&exportAll, "all", false, "Export every saved project")
+	exportCmd.Flags().StringVar(&exportFormat, "format", export.FormatMarkdown, "Output format: md|json|yaml|html")
+	exportCmd.Flags().StringVar(&exportOutDir, "out", ".", "Directory to write exported files into")
+	exportCmd.Flags().StringVar(&exportTemplateDir, "templates", "", "Directory with project.<format>.tmpl overrides (default $XDG_CONFIG_HOME/quibit/templates)")
+}
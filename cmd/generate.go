@@ -8,15 +8,18 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"quibit/internal/ai"
-	"quibit/internal/db"
+	"quibit/internal/domain"
 	"quibit/internal/model"
+	"quibit/internal/persistence"
 	pmodels "quibit/internal/persistence/models"
 	"quibit/internal/project"
+	"quibit/internal/similarity"
 	"quibit/internal/tui"
 	tuiinput "quibit/internal/tui/input"
 
@@ -30,6 +33,28 @@ var generateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		out := cmd.OutOrStdout()
 		ctx := cmd.Context()
+		if generateHeadlessRequested(cmd) || structuredOutput() {
+			input, err := projectInputFromFlags(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			opts := generateOptions{Headless: true, AutoAccept: genYes, Ensemble: genEnsemble}
+			if structuredOutput() {
+				opts.Docs = out
+				out = cmd.ErrOrStderr()
+			}
+			store, err := openStore(ctx)
+			if err != nil {
+				return err
+			}
+			defer closeStore(store)
+			return runGenerateWithInput(ctx, store, os.Stdin, out, input, opts)
+		}
+		store, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStore(store)
 		for {
 			tui.AppHeader(out)
 			tui.Context(out, "Select a mode.")
@@ -49,22 +74,22 @@ var generateCmd = &cobra.Command{
 			switch selection.ID {
 			case "new":
 				tui.Transition(ctx, out)
-				if err := runGenerateNew(ctx, os.Stdin, out); err != nil {
+				if err := runGenerateNew(ctx, store, os.Stdin, out); err != nil {
 					return err
 				}
 			case "idea":
 				tui.Transition(ctx, out)
-				if err := runGenerateFromUserIdea(ctx, os.Stdin, out); err != nil {
+				if err := runGenerateFromUserIdea(ctx, store, os.Stdin, out); err != nil {
 					return err
 				}
 			case "continue":
 				tui.Transition(ctx, out)
-				if err := runContinueExisting(ctx, os.Stdin, out); err != nil {
+				if err := runContinueExisting(ctx, store, os.Stdin, out, continueOptions{}); err != nil {
 					return err
 				}
 			case "view":
 				tui.Transition(ctx, out)
-				if err := runViewSavedProjects(ctx, out); err != nil {
+				if err := runViewSavedProjects(ctx, store, out); err != nil {
 					return err
 				}
 			case "exit":
@@ -76,32 +101,47 @@ var generateCmd = &cobra.Command{
 	},
 }
 
-func runGenerateNew(ctx context.Context, in *os.File, out io.Writer) error {
+func runGenerateNew(ctx context.Context, store persistence.Store, in *os.File, out io.Writer) error {
 	input, err := tuiinput.CollectNewProjectInput(in, out)
 	if err != nil {
 		return err
 	}
-	return runGenerateWithInput(ctx, in, out, input)
+	return runGenerateWithInput(ctx, store, in, out, input, generateOptions{Ensemble: genEnsemble})
 }
 
-func runGenerateFromUserIdea(ctx context.Context, in *os.File, out io.Writer) error {
+func runGenerateFromUserIdea(ctx context.Context, store persistence.Store, in *os.File, out io.Writer) error {
 	input, err := tuiinput.CollectUserIdeaProjectInput(in, out)
 	if err != nil {
 		return err
 	}
-	return runGenerateWithInput(ctx, in, out, input)
+	return runGenerateWithInput(ctx, store, in, out, input, generateOptions{Ensemble: genEnsemble})
 }
 
-func runGenerateWithInput(ctx context.Context, in *os.File, out io.Writer, input model.ProjectInput) error {
+type generateOptions struct {
+	Headless   bool
+	AutoAccept bool
+	Ensemble   bool
+	Docs       io.Writer
+	Readiness  bool
+	Built      []string
+	Strict     bool
+}
+
+const maxHeadlessRegenerations = 3
+
+func runGenerateWithInput(ctx context.Context, store persistence.Store, in *os.File, out io.Writer, input model.ProjectInput, opts generateOptions) error {
 	var pendingReason *ai.RetryReason
 	var pendingStrategy ai.PivotStrategy
+	var pendingMatch *similarityMatch
+	var history regenerationHistory
 	var lastReasonUsed *ai.RetryReason
 	var lastMeta ai.AIResult
 	var err error
+	regenerations := 0
 generateLoop:
 	for {
 		if pendingReason == nil {
-			preDecision, preScore, preErr := evaluateSimilarityPre(ctx, input)
+			preDecision, preScore, preErr := evaluateSimilarityPre(ctx, store, input)
 			if preErr != nil {
 				return preErr
 			}
@@ -114,36 +154,151 @@ generateLoop:
 
 		var idea ai.ProjectIdea
 		var rawJSON string
+		var ensemble []ai.EnsembleCandidate
 		spin := tui.StartSpinner(ctx, out, "Generating project blueprint")
-		if pendingReason == nil {
+		if opts.Ensemble {
+			lastReasonUsed = pendingReason
+			prompt := ai.BuildProjectIdeaPrompt(input)
+			if pendingReason != nil {
+				avoid, refs := history.avoid(pendingMatch)
+				prompt = ai.BuildProjectIdeaPivotPrompt(input, *pendingReason, pendingStrategy, avoid, refs...)
+			}
+			ensemble, err = ai.GenerateProjectIdeaEnsemble(ctx, input, prompt)
+			pendingReason = nil
+			pendingMatch = nil
+		} else if pendingReason == nil {
 			lastReasonUsed = nil
-			idea, rawJSON, lastMeta, err = ai.GenerateProjectIdeaOnceWithMeta(ctx, input)
+			if opts.Headless {
+				idea, rawJSON, lastMeta, err = ai.GenerateProjectIdeaWithMeta(ctx, input)
+			} else {
+				idea, rawJSON, lastMeta, err = ai.GenerateProjectIdeaOnceWithMeta(ctx, input)
+			}
 		} else {
 			lastReasonUsed = pendingReason
-			idea, rawJSON, lastMeta, err = ai.GenerateProjectIdeaWithPivotOnceMeta(ctx, input, *pendingReason, pendingStrategy)
+			avoid, refs := history.avoid(pendingMatch)
+			if opts.Headless {
+				idea, rawJSON, lastMeta, err = ai.GenerateProjectIdeaWithPivotMeta(ctx, input, *pendingReason, pendingStrategy, avoid, refs...)
+			} else {
+				idea, rawJSON, lastMeta, err = ai.GenerateProjectIdeaWithPivotOnceMeta(ctx, input, *pendingReason, pendingStrategy, avoid, refs...)
+			}
 			pendingReason = nil
+			pendingMatch = nil
 		}
 		spin.Stop()
 		if err != nil {
-			return fmt.Errorf("generate: %w", err)
+			return classifyGenerateError(fmt.Errorf("generate: %w", err))
 		}
 
 		simSpin := tui.StartSpinner(ctx, out, "Syncing with saved projects")
-		action, bestScore, err := evaluateSimilarity(ctx, idea, input)
-		simSpin.Stop()
+		var action project.SimilarityDecision
+		var bestScore float64
+		var match *similarityMatch
+		if ensemble != nil {
+			pick, err := pickEnsembleCandidate(ctx, store, out, input, ensemble)
+			simSpin.Stop()
+			if err != nil {
+				return err
+			}
+			printEnsembleSummary(out, ensemble, pick)
+			winner := ensemble[pick.Winner]
+			if winner.Err != nil {
+				// Candidates are ranked decoded-first, so every provider
+				// answered without a usable idea.
+				return classifyGenerateError(fmt.Errorf("generate: %w: no ensemble answer could be decoded: %s", ai.ErrProvidersFailed, winner.Summary()))
+			}
+			if opts.Headless && !winner.Accepted() {
+				if regenerations >= maxHeadlessRegenerations {
+					return classifyGenerateError(fmt.Errorf("generate: %w: no ensemble candidate passed after %d regenerations: %s", ai.ErrQualityGateFailed, regenerations, winner.Summary()))
+				}
+				regenerations++
+				tui.Status(out, "No ensemble candidate passed the quality gate; regenerating")
+				history.reject(winner.Idea, pick.Match)
+				pendingReason = ptrRetry(ai.RetryQualityTooGeneric)
+				pendingStrategy = winner.RetryStrategy(regenerations)
+				continue
+			}
+			idea, rawJSON, lastMeta = winner.Idea, winner.RawJSON, pick.Meta
+			action, bestScore, match = pick.Action, pick.Score, pick.Match
+		} else {
+			action, bestScore, match, err = evaluateSimilarity(ctx, store, out, idea, input)
+			simSpin.Stop()
+		}
 		if err != nil {
 			return err
 		}
+		if action != project.SimilarityOK && opts.Docs == nil {
+			printSimilarityBreakdown(out, newSimilarityBreakdownDocument(match))
+		}
 		switch action {
 		case project.SimilarityRegenerate:
+			if opts.Headless {
+				if regenerations >= maxHeadlessRegenerations {
+					return withExitCode(exitSimilarityBlocked, fmt.Errorf("generate: similarity %.2f is still too high after %d regenerations", bestScore, regenerations))
+				}
+				regenerations++
+				tui.Status(out, fmt.Sprintf("Similarity %.2f is high; regenerating", bestScore))
+				history.reject(idea, match)
+				pendingReason = ptrRetry(ai.RetrySimilarityTooHigh)
+				pendingStrategy = selectPivotStrategy(ai.RetrySimilarityTooHigh)
+				if match != nil {
+					pendingStrategy, pendingMatch = match.pivotStrategy(), match
+				}
+				continue
+			}
 			tui.Status(out, fmt.Sprintf("Similarity %.2f is high; you may choose to regenerate", bestScore))
 		case project.SimilarityBlock:
+			if opts.Headless {
+				return withExitCode(exitSimilarityBlocked, fmt.Errorf("generate: blocked: similarity %.2f is too high", bestScore))
+			}
 			tui.PrintError(out, "Generation blocked", fmt.Errorf("similarity %.2f is too high", bestScore))
 			return nil
 		default:
 		}
 
-		printIdea(out, idea, input)
+		if opts.Docs == nil {
+			printIdea(out, idea, input)
+		}
+
+		if opts.Headless {
+			doc := projectDocument{
+				Type:       "project",
+				Input:      newInputDocument(input),
+				Idea:       idea,
+				AI:         newAIMetaDocument(lastMeta, lastReasonUsed),
+				Similarity: &similarityDocument{Score: bestScore, Decision: similarityDecisionName(action), Breakdown: newSimilarityBreakdownDocument(match)},
+				Quality:    newQualityScoresDocument(lastMeta.Quality),
+			}
+			if !opts.AutoAccept {
+				if opts.Docs != nil {
+					return emitDocument(opts.Docs, doc)
+				}
+				tui.BlankLine(out)
+				tui.Hint(out, "Not saved. Pass --yes to accept and save the result.")
+				return nil
+			}
+			saveSpin := tui.StartSpinner(ctx, out, "Saving project")
+			projectID, err := saveGeneratedProject(ctx, store, input, idea, rawJSON, lastMeta, lastReasonUsed, bestScore, match)
+			saveSpin.Stop()
+			if err != nil {
+				if errors.Is(err, persistence.ErrDuplicateDNA) && regenerations < maxHeadlessRegenerations {
+					regenerations++
+					tui.Status(out, "Duplicate result detected; regenerating")
+					history.reject(idea, match)
+					pendingReason = ptrRetry(ai.RetryDuplicateDNA)
+					pendingStrategy = selectPivotStrategy(ai.RetryDuplicateDNA)
+					continue
+				}
+				return err
+			}
+			if opts.Docs != nil {
+				doc.ProjectID = projectID.String()
+				doc.Saved = true
+				return emitDocument(opts.Docs, doc)
+			}
+			tui.BlankLine(out)
+			tui.Done(out, "Saved")
+			return nil
+		}
 
 		selection, err := tui.SelectOption(in, out, "Choose next action.", []tui.Option{
 			{ID: "accept", Label: "Accept and save"},
@@ -158,11 +313,12 @@ generateLoop:
 		switch selection.ID {
 		case "accept":
 			saveSpin := tui.StartSpinner(ctx, out, "Saving project")
-			err := saveGeneratedProject(ctx, input, idea, rawJSON, lastMeta, lastReasonUsed)
+			_, err := saveGeneratedProject(ctx, store, input, idea, rawJSON, lastMeta, lastReasonUsed, bestScore, match)
 			saveSpin.Stop()
 			if err != nil {
-				if errors.Is(err, errDuplicateDNA) {
+				if errors.Is(err, persistence.ErrDuplicateDNA) {
 					tui.Status(out, "Duplicate result detected; regenerating")
+					history.reject(idea, match)
 					pendingReason = ptrRetry(ai.RetryDuplicateDNA)
 					pendingStrategy = selectPivotStrategy(ai.RetryDuplicateDNA)
 					continue
@@ -211,11 +367,16 @@ generateLoop:
 				}
 			}
 		case "regenerate":
+			history.reject(idea, match)
 			pendingReason = ptrRetry(ai.RetryUserRejected)
 			pendingStrategy = selectPivotStrategy(ai.RetryUserRejected)
+			if action == project.SimilarityRegenerate && match != nil {
+				pendingStrategy, pendingMatch = match.pivotStrategy(), match
+			}
 			continue
 		case "regenerate_harder":
 			input.Complexity = bumpComplexity(input.Complexity)
+			history.reject(idea, match)
 			pendingReason = ptrRetry(ai.RetryUserRejected)
 			pendingStrategy = selectPivotStrategy(ai.RetryUserRejected)
 			continue
@@ -227,89 +388,43 @@ generateLoop:
 	}
 }
 
-func evaluateSimilarityPre(ctx context.Context, input model.ProjectInput) (project.SimilarityDecision, float64, error) {
-	gdb, err := db.Connect(ctx)
+func evaluateSimilarityPre(ctx context.Context, store persistence.Store, input model.ProjectInput) (project.SimilarityDecision, float64, error) {
+	current := inputSnapshot(input)
+	rows, err := similarityCandidates(ctx, store, lshBuckets("input", current))
 	if err != nil {
 		return project.SimilarityOK, 0, fmt.Errorf("generate: %w", err)
 	}
-	sqlDB, err := gdb.DB()
-	if err != nil {
-		return project.SimilarityOK, 0, fmt.Errorf("generate: get sql db: %w", err)
-	}
-	defer func() {
-		_ = sqlDB.Close()
-	}()
-
-	limit := loadSimilarityLimit()
-	var rows []pmodels.Project
-	if err := gdb.Order("created_at desc").Limit(limit).Find(&rows).Error; err != nil {
-		return project.SimilarityOK, 0, fmt.Errorf("generate: load recent projects: %w", err)
-	}
 	if len(rows) == 0 {
 		return project.SimilarityOK, 0, nil
 	}
 
-	current := project.Snapshot{
-		Overview:          "",
-		MVPScope:          []string{},
-		TechStack:         input.TechStack,
-		Complexity:        input.Complexity,
-		EstimatedDuration: input.Timeframe,
-		AppType:           input.AppType,
-		Goal:              input.Goal,
-	}
-
 	best := 0.0
 	for _, row := range rows {
 		stack, err := parseStringArray(row.TechStackJSON)
 		if err != nil {
 			return project.SimilarityOK, 0, fmt.Errorf("generate: parse tech stack: %w", err)
 		}
-
-		prev := project.Snapshot{
-			Overview:          "",
-			MVPScope:          []string{},
-			TechStack:         stack,
-			Complexity:        row.Complexity,
-			EstimatedDuration: row.Duration,
-			AppType:           row.AppType,
-			Goal:              row.Goal,
-		}
-		score := project.JaccardSimilarity(current, prev)
+		score := project.JaccardSimilarity(current, rowInputSnapshot(row, stack))
 		if score > best {
 			best = score
 		}
 	}
 
-	return project.DecideSimilarity(best), best, nil
+	return decideSimilarity(best), best, nil
 }
 
-var errDuplicateDNA = errors.New("duplicate dna")
-
-func saveGeneratedProject(ctx context.Context, input model.ProjectInput, idea ai.ProjectIdea, rawJSON string, meta ai.AIResult, retryReason *ai.RetryReason) error {
-	gdb, err := db.Connect(ctx)
-	if err != nil {
-		return fmt.Errorf("generate: %w", err)
-	}
-	sqlDB, err := gdb.DB()
-	if err != nil {
-		return fmt.Errorf("generate: get sql db: %w", err)
-	}
-	defer func() {
-		_ = sqlDB.Close()
-	}()
-
+func saveGeneratedProject(ctx context.Context, store persistence.Store, input model.ProjectInput, idea ai.ProjectIdea, rawJSON string, meta ai.AIResult, retryReason *ai.RetryReason, similarityScore float64, match *similarityMatch) (uuid.UUID, error) {
 	mvp := idea.Project.MVP.MustHave
 	stack := flattenTechStack(idea.Project.TechStack)
 	overview := buildProjectOverview(idea)
 
 	mvpJSON, err := json.Marshal(mvp)
 	if err != nil {
-		return fmt.Errorf("generate: marshal mvp scope: %w", err)
+		return uuid.Nil, fmt.Errorf("generate: marshal mvp scope: %w", err)
 	}
 	techJSON, err := json.Marshal(stack)
 	if err != nil {
-		return fmt.Errorf("generate: marshal tech stack: %w", err)
+		return uuid.Nil, fmt.Errorf("generate: marshal tech stack: %w", err)
 	}
 
 	providerUsed := strings.TrimSpace(meta.ProviderUsed)
@@ -343,7 +458,7 @@ func saveGeneratedProject(ctx context.Context, input model.ProjectInput, idea ai
 		Duration:   idea.Project.Duration.Range,
 
 		DNAHash:         project.HashContent(overview, mvp, stack, idea.Project.Complexity, idea.Project.Duration.Range),
-		SimilarityScore: 0,
+		SimilarityScore: similarityScore,
 		PivotReason:     retryPtr,
 
 		AIProvider:    providerUsed,
@@ -355,18 +470,12 @@ func saveGeneratedProject(ctx context.Context, input model.ProjectInput, idea ai
 
 		CreatedAt: time.Now(),
 	}
-
-	tx := gdb.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return fmt.Errorf("generate: save project: begin transaction: %w", tx.Error)
+	if match != nil {
+		similarID := match.ProjectID
+		row.SimilarProjectID = &similarID
 	}
-	defer func() { _ = tx.Rollback() }()
-
-	if err := tx.Create(&row).Error; err != nil {
-		if isUniqueViolation(err) {
-			return errDuplicateDNA
-		}
-		return fmt.Errorf("generate: save project: %w", err)
+	if err := applyQualityScores(&row, meta.Quality); err != nil {
+		return uuid.Nil, fmt.Errorf("generate: %w", err)
 	}
 
 	var features []pmodels.ProjectFeature
@@ -391,19 +500,13 @@ func saveGeneratedProject(ctx context.Context, input model.ProjectInput, idea ai
 	appendFeatures("learning_outcome", idea.Project.Learning)
 	appendFeatures("key_benefit", idea.Project.ValueProp.KeyBenefits)
 
-	if len(features) > 0 {
-		if err := tx.Create(&features).Error; err != nil {
-			return fmt.Errorf("generate: save project features: %w", err)
-		}
-	}
-
 	targetUsersJSON, err := json.Marshal(map[string]any{
 		"primary":   idea.Project.TargetUsers.Primary,
 		"secondary": idea.Project.TargetUsers.Secondary,
 		"use_cases": idea.Project.TargetUsers.UseCases,
 	})
 	if err != nil {
-		return fmt.Errorf("generate: marshal target users: %w", err)
+		return uuid.Nil, fmt.Errorf("generate: marshal target users: %w", err)
 	}
 	metaRow := pmodels.ProjectMeta{
 		ProjectID:   row.ID,
@@ -411,76 +514,125 @@ func saveGeneratedProject(ctx context.Context, input model.ProjectInput, idea ai
 		TechStack:   strings.Join(stack, ", "),
 		RawAIOutput: rawJSON,
 	}
-	if err := tx.Create(&metaRow).Error; err != nil {
-		return fmt.Errorf("generate: save project meta: %w", err)
+	dnaRow, err := newProjectDNARow(row.ID, ai.ExtractProjectDNA(idea, input.AppType, input.ProjectKind))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("generate: %w", err)
 	}
-
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("generate: save project: commit: %w", err)
+	record := persistence.ProjectRecord{Project: row, Features: features, Meta: metaRow, DNA: dnaRow}
+	if match != nil {
+		similarityRow := newProjectSimilarityRow(row.ID, match)
+		record.Similarity = &similarityRow
 	}
-
-	return nil
-}
-
-func evaluateSimilarity(ctx context.Context, idea ai.ProjectIdea, input model.ProjectInput) (project.SimilarityDecision, float64, error) {
-	gdb, err := db.Connect(ctx)
-	if err != nil {
-		return project.SimilarityOK, 0, fmt.Errorf("generate: %w", err)
+	// The idea was embedded while checking similarity; without that vector
+	// (empty library, embeddings off) the next similarity check backfills it.
+	if match != nil && len(match.Vector) > 0 {
+		embeddingRow, err := newProjectEmbeddingRow(row.ID, match.VectorModel, match.Vector)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("generate: %w", err)
+		}
+		record.Embedding = &embeddingRow
 	}
-	sqlDB, err := gdb.DB()
+	record.LSHBuckets, err = projectLSHBuckets(row)
 	if err != nil {
-		return project.SimilarityOK, 0, fmt.Errorf("generate: get sql db: %w", err)
+		return uuid.Nil, fmt.Errorf("generate: %w", err)
 	}
-	defer func() {
-		_ = sqlDB.Close()
-	}()
+	if err := store.SaveProject(ctx, record); err != nil {
+		return uuid.Nil, fmt.Errorf("generate: %w", err)
+	}
+
+	return row.ID, nil
+}
 
-	limit := loadSimilarityLimit()
-	var rows []pmodels.Project
-	if err := gdb.Order("created_at desc").Limit(limit).Find(&rows).Error; err != nil {
-		return project.SimilarityOK, 0, fmt.Errorf("generate: load recent projects: %w", err)
+// evaluateSimilarity scores idea against recent saved projects. The score is
+// the token Jaccard blended with embedding cosine similarity
+// (SIMILARITY_EMBEDDING_WEIGHT); the closest project comes back with its
+// per-dimension breakdown.
+func evaluateSimilarity(ctx context.Context, store persistence.Store, out io.Writer, idea ai.ProjectIdea, input model.ProjectInput) (project.SimilarityDecision, float64, *similarityMatch, error) {
+	current := ideaSnapshot(idea, input)
+	rows, err := similarityCandidates(ctx, store, lshBuckets("idea", current))
+	if err != nil {
+		return project.SimilarityOK, 0, nil, fmt.Errorf("generate: %w", err)
 	}
 	if len(rows) == 0 {
-		return project.SimilarityOK, 0, nil
+		return project.SimilarityOK, 0, nil, nil
 	}
 
-	current := project.Snapshot{
-		Overview:          buildProjectOverview(idea),
-		MVPScope:          idea.Project.MVP.MustHave,
-		TechStack:         flattenTechStack(idea.Project.TechStack),
-		Complexity:        idea.Project.Complexity,
-		EstimatedDuration: idea.Project.Duration.Range,
-		AppType:           input.AppType,
-		Goal:              input.Goal,
-	}
+	currentProject := ai.DomainProject(idea)
 
-	best := 0.0
-	for _, row := range rows {
-		mvp, err := parseStringArray(row.MVPScopeJSON)
+	weight := similarity.LoadEmbeddingWeightFromEnv()
+	var currentVec []float32
+	var vectors map[uuid.UUID][]float32
+	var vectorModel string
+	if weight > 0 {
+		ix, err := newSemanticIndex(store)
 		if err != nil {
-			return project.SimilarityOK, 0, fmt.Errorf("generate: parse mvp scope: %w", err)
+			return project.SimilarityOK, 0, nil, fmt.Errorf("generate: %w", err)
 		}
-		stack, err := parseStringArray(row.TechStackJSON)
+		currentVec, vectors, err = ix.compare(ctx, similarity.EmbeddingText(currentProject), rows)
 		if err != nil {
-			return project.SimilarityOK, 0, fmt.Errorf("generate: parse tech stack: %w", err)
+			return project.SimilarityOK, 0, nil, fmt.Errorf("generate: embed: %w", err)
+		}
+		vectorModel = ix.embedder.Model()
+		if ix.fallback != nil {
+			tui.Hint(out, fmt.Sprintf("Embedder unavailable (%v); using local embeddings", ix.fallback))
 		}
+	}
 
-		prev := project.Snapshot{
-			Overview:          row.ProjectOverview,
-			MVPScope:          mvp,
-			TechStack:         stack,
-			Complexity:        row.Complexity,
-			EstimatedDuration: row.Duration,
-			AppType:           row.AppType,
-			Goal:              row.Goal,
+	best, bestSemantic := 0.0, 0.0
+	closest := -1
+	scores := make([]float64, len(rows))
+	for i, row := range rows {
+		prev, err := rowSnapshot(row)
+		if err != nil {
+			return project.SimilarityOK, 0, nil, fmt.Errorf("generate: %w", err)
 		}
-		score := project.JaccardSimilarity(current, prev)
-		if score > best {
-			best = score
+		lexical := project.JaccardSimilarity(current, prev)
+		semantic := similarity.Cosine(currentVec, vectors[row.ID])
+		scores[i] = similarity.Blend(lexical, semantic, weight)
+		if scores[i] > best {
+			best, bestSemantic = scores[i], semantic
+			closest = i
+		}
+	}
+	if closest < 0 {
+		return decideSimilarity(best), best, nil, nil
+	}
+
+	ref, err := savedDomainProject(rows[closest])
+	if err != nil {
+		return project.SimilarityOK, 0, nil, err
+	}
+	match := newSimilarityMatch(currentProject, rows[closest], ref)
+	match.Semantic = bestSemantic
+	match.Score = best
+	match.Vector, match.VectorModel = currentVec, vectorModel
+
+	order := make([]int, 0, len(rows))
+	for i := range rows {
+		if i != closest && scores[i] > 0 {
+			order = append(order, i)
 		}
 	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+	if len(order) > similarNeighborCount {
+		order = order[:similarNeighborCount]
+	}
+	for _, i := range order {
+		p, err := savedDomainProject(rows[i])
+		if err != nil {
+			return project.SimilarityOK, 0, nil, err
+		}
+		match.Neighbors = append(match.Neighbors, similarNeighbor{ProjectID: rows[i].ID, Project: p})
+	}
+	return decideSimilarity(best), best, match, nil
+}
 
-	return project.DecideSimilarity(best), best, nil
+func savedDomainProject(row pmodels.Project) (domain.Project, error) {
+	var idea ai.ProjectIdea
+	if err := json.Unmarshal([]byte(row.RawAIOutput), &idea); err != nil {
+		return domain.Project{}, fmt.Errorf("generate: parse saved raw_ai_output: %w", err)
+	}
+	return ai.DomainProject(idea), nil
 }
 
 func parseStringArray(raw string) ([]string, error) {
@@ -601,82 +753,102 @@ func makeSnapshotFromIdea(idea ai.ProjectIdea, input model.ProjectInput) project
 
 func ptrSnapshot(s project.Snapshot) *project.Snapshot { return &s }
 
-func isUniqueViolation(err error) bool {
-	if err == nil {
-		return false
-	}
-	s := strings.ToLower(err.Error())
-	if strings.Contains(s, "sqlstate 23505") {
-		return true
-	}
-	if strings.Contains(s, "duplicate key") {
-		return true
-	}
-	if strings.Contains(s, "unique constraint") {
-		return true
-	}
-	return false
+type continueOptions struct {
+	ProjectID  string
+	Headless   bool
+	AutoAccept bool
+	Docs       io.Writer
+	Readiness  bool
+	Built      []string
+	Strict     bool
 }
 
-func runContinueExisting(ctx context.Context, _ *os.File, out io.Writer) error {
-	loadSpin := tui.StartSpinner(ctx, out, "Loading saved projects")
-	projects, err := loadRecentProjects(ctx)
-	loadSpin.Stop()
-	if err != nil {
-		return err
-	}
-	if len(projects) == 0 {
-		fmt.Fprintln(out, "No existing projects found.")
-		return nil
-	}
+func runContinueExisting(ctx context.Context, store persistence.Store, _ *os.File, out io.Writer, opts continueOptions) error {
+	var selected *pmodels.Project
+	if strings.TrimSpace(opts.ProjectID) != "" {
+		loadSpin := tui.StartSpinner(ctx, out, "Loading project")
+		p, err := loadProject(ctx, store, opts.ProjectID)
+		loadSpin.Stop()
+		if err != nil {
+			return err
+		}
+		selected = p
+	} else {
+		loadSpin := tui.StartSpinner(ctx, out, "Loading saved projects")
+		projects, err := loadRecentProjects(ctx, store)
+		loadSpin.Stop()
+		if err != nil {
+			return err
+		}
+		if len(projects) == 0 {
+			fmt.Fprintln(out, "No existing projects found.")
+			return nil
+		}
 
-	options := make([]tui.Option, 0, len(projects))
-	for _, p := range projects {
-		options = append(options, tui.Option{
-			ID:    p.ID.String(),
-			Label: fmt.Sprintf("%s (%s, %s)", p.ProjectOverview, p.Complexity, p.Duration),
-		})
-	}
+		options := make([]tui.Option, 0, len(projects))
+		for _, p := range projects {
+			options = append(options, tui.Option{
+				ID:    p.ID.String(),
+				Label: fmt.Sprintf("%s (%s, %s)", p.ProjectOverview, p.Complexity, p.Duration),
+			})
+		}
 
-	selection, err := tui.SelectOption(os.Stdin, out, "Select a project:", options)
-	if err != nil {
-		return err
-	}
+		selection, err := tui.SelectOption(os.Stdin, out, "Select a project:", options)
+		if err != nil {
+			return err
+		}
 
-	var selected *pmodels.Project
-	for i := range projects {
-		if projects[i].ID.String() == selection.ID {
-			selected = &projects[i]
-			break
+		for i := range projects {
+			if projects[i].ID.String() == selection.ID {
+				selected = &projects[i]
+				break
+			}
+		}
+		if selected == nil {
+			return fmt.Errorf("continue: invalid selection")
 		}
-	}
-	if selected == nil {
-		return fmt.Errorf("continue: invalid selection")
 	}
 
-	mvp, err := parseStringArray(selected.MVPScopeJSON)
+	chain, err := loadEvolutionChain(ctx, store, selected)
 	if err != nil {
-		return fmt.Errorf("continue: parse mvp scope: %w", err)
+		return fmt.Errorf("continue: %w", err)
 	}
-	stack, err := parseStringArray(selected.TechStackJSON)
+	mvp := chain.Scope()
+	baseStack, err := parseStringArray(selected.TechStackJSON)
 	if err != nil {
 		return fmt.Errorf("continue: parse tech stack: %w", err)
 	}
+	stack := ai.EvolvedStack(baseStack, chain.Phases)
+	dna, err := ensureProjectDNA(ctx, store, selected)
+	if err != nil {
+		return fmt.Errorf("continue: %w", err)
+	}
 
 	fmt.Fprintln(out, "")
 	fmt.Fprintln(out, "Project Context")
 	fmt.Fprintln(out, selected.ProjectOverview)
 	fmt.Fprintln(out, "")
-	fmt.Fprintln(out, "MVP Scope")
+	if n := len(chain.Phases); n > 0 {
+		fmt.Fprintf(out, "Current Scope (after %d evolutions)\n", n)
+	} else {
+		fmt.Fprintln(out, "MVP Scope")
+	}
 	for _, item := range mvp {
 		fmt.Fprintf(out, "- %s\n", item)
 	}
 	fmt.Fprintln(out, "")
-	fmt.Fprintln(out, "Tech Stack")
+	if n := len(chain.Phases); n > 0 {
+		fmt.Fprintf(out, "Tech Stack (after %d evolutions)\n", n)
+	} else {
+		fmt.Fprintln(out, "Tech Stack")
+	}
 	for _, item := range stack {
 		fmt.Fprintf(out, "- %s\n", item)
 	}
 	fmt.Fprintln(out, "")
+	fmt.Fprintln(out, "Architecture")
+	fmt.Fprintln(out, dna.ArchitecturalStyle)
+	fmt.Fprintln(out, "")
 	fmt.Fprintln(out, "Complexity")
 	fmt.Fprintln(out, selected.Complexity)
 	fmt.Fprintln(out, "")
@@ -688,50 +860,121 @@ func runContinueExisting(ctx context.Context, _ *os.File, out io.Writer) error {
 	fmt.Fprintln(out, "")
 	fmt.Fprintln(out, "Goal")
 	fmt.Fprintln(out, selected.Goal)
-	return runProjectEvolution(ctx, out, selected, mvp, stack)
+	return runProjectEvolution(ctx, store, out, selected, mvp, stack, dna, chain.Phases, opts)
 }
 
-func loadRecentProjects(ctx context.Context) ([]pmodels.Project, error) {
-	gdb, err := db.Connect(ctx)
+func loadProject(ctx context.Context, store persistence.Store, id string) (*pmodels.Project, error) {
+	projectID, err := uuid.Parse(strings.TrimSpace(id))
 	if err != nil {
-		return nil, fmt.Errorf("continue: %w", err)
+		return nil, withExitCode(exitUsage, fmt.Errorf("continue: invalid project id %q", id))
+	}
+
+	row, err := store.GetProject(ctx, projectID)
+	if errors.Is(err, persistence.ErrNotFound) {
+		return nil, fmt.Errorf("continue: project %s not found", projectID)
 	}
-	sqlDB, err := gdb.DB()
 	if err != nil {
-		return nil, fmt.Errorf("continue: get sql db: %w", err)
+		return nil, fmt.Errorf("continue: %w", err)
 	}
-	defer func() {
-		_ = sqlDB.Close()
-	}()
+	return &row, nil
+}
 
-	limit := loadSimilarityLimit()
-	var rows []pmodels.Project
-	if err := gdb.Order("created_at desc").Limit(limit).Find(&rows).Error; err != nil {
-		return nil, fmt.Errorf("continue: load projects: %w", err)
+func loadRecentProjects(ctx context.Context, store persistence.Store) ([]pmodels.Project, error) {
+	rows, err := store.ListRecentProjects(ctx, loadSimilarityLimit())
+	if err != nil {
+		return nil, fmt.Errorf("continue: %w", err)
 	}
 	return rows, nil
 }
 
-func runProjectEvolution(ctx context.Context, out io.Writer, selected *pmodels.Project, mvp []string, stack []string) error {
-	input := ai.EvolutionInput{
-		ProjectOverview:   selected.ProjectOverview,
-		MVPScope:          mvp,
-		TechStack:         stack,
-		Complexity:        selected.Complexity,
-		EstimatedDuration: selected.Duration,
-		AppType:           selected.AppType,
-		Goal:              selected.Goal,
+func runProjectEvolution(ctx context.Context, store persistence.Store, out io.Writer, selected *pmodels.Project, mvp []string, stack []string, dna project.ProjectDNA, history []ai.EvolutionPhase, opts continueOptions) error {
+	input := ai.NextPhaseEvolutionPromptInput{
+		CurrentProjectOverview: selected.ProjectOverview,
+		TechStack:              stack,
+		MVPScope:               mvp,
+		ProjectDNA:             dna,
+		PreviousPhases:         history,
+	}
+
+	reviewFirst := opts.Readiness
+	if !opts.Headless && !reviewFirst {
+		selection, err := tui.SelectOption(os.Stdin, out, "Choose next step.", []tui.Option{
+			{ID: "evolve", Label: "Generate next evolution"},
+			{ID: "readiness", Label: "Review readiness first"},
+			{ID: "back", Label: "Back"},
+		})
+		if err != nil {
+			return err
+		}
+		switch selection.ID {
+		case "evolve":
+		case "readiness":
+			reviewFirst = true
+		case "back":
+			return nil
+		default:
+			return fmt.Errorf("continue: invalid selection")
+		}
+	}
+
+	var readiness *readinessDocument
+	if reviewFirst {
+		doc, err := runReadinessReview(ctx, store, out, selected, mvp, stack, opts)
+		if err != nil {
+			return err
+		}
+		readiness = &doc
+		if doc.Verdict != ai.ReadinessReady {
+			proceed, err := readinessGate(out, doc, opts)
+			if err != nil || !proceed {
+				return err
+			}
+		}
 	}
 
 	for {
 		spin := tui.StartSpinner(ctx, out, "Generating next evolution")
-		evo, rawJSON, meta, err := ai.GenerateProjectEvolutionWithMeta(ctx, input)
+		evo, rawJSON, meta, err := ai.GenerateNextPhaseEvolutionWithMeta(ctx, input)
 		spin.Stop()
 		if err != nil {
-			return fmt.Errorf("continue: %w", err)
+			return classifyGenerateError(fmt.Errorf("continue: %w", err))
+		}
+
+		if opts.Docs == nil {
+			printNextPhaseEvolution(out, evo)
 		}
 
-		printEvolution(out, evo)
+		if opts.Headless {
+			doc := evolutionDocument{
+				Type:      "evolution",
+				ProjectID: selected.ID.String(),
+				Contract:  ai.EvolutionContractNextPhase,
+				NextPhase: &evo,
+				AI:        newAIMetaDocument(meta, nil),
+				Readiness: readiness,
+			}
+			if opts.AutoAccept {
+				saveSpin := tui.StartSpinner(ctx, out, "Saving evolution")
+				evolutionID, version, err := saveNextPhaseEvolution(ctx, store, selected.ID, evo, rawJSON, meta)
+				saveSpin.Stop()
+				if err != nil {
+					return err
+				}
+				doc.EvolutionID = evolutionID.String()
+				doc.ScopeVersion = version
+				doc.Saved = true
+			}
+			if opts.Docs != nil {
+				return emitDocument(opts.Docs, doc)
+			}
+			tui.BlankLine(out)
+			if doc.Saved {
+				tui.Done(out, "Saved")
+			} else {
+				tui.Hint(out, "Not saved. Pass --yes to accept and save the evolution.")
+			}
+			return nil
+		}
 
 		selection, err := tui.SelectOption(os.Stdin, out, "Choose next action.", []tui.Option{
 			{ID: "accept", Label: "Accept and save"},
@@ -745,12 +988,12 @@ func runProjectEvolution(ctx context.Context, out io.Writer, selected *pmodels.P
 		switch selection.ID {
 		case "accept":
 			saveSpin := tui.StartSpinner(ctx, out, "Saving evolution")
-			err := saveProjectEvolution(ctx, selected.ID, rawJSON, meta)
+			_, version, err := saveNextPhaseEvolution(ctx, store, selected.ID, evo, rawJSON, meta)
 			saveSpin.Stop()
 			if err != nil {
 				return err
 			}
-			tui.Done(out, "Saved")
+			tui.Done(out, fmt.Sprintf("Saved as MVP scope v%d", version))
 			return nil
 		case "regenerate":
 			continue
@@ -762,46 +1005,36 @@ func runProjectEvolution(ctx context.Context, out io.Writer, selected *pmodels.P
 	}
 }
 
-func saveProjectEvolution(ctx context.Context, projectID uuid.UUID, rawJSON string, meta ai.AIResult) error {
-	gdb, err := db.Connect(ctx)
-	if err != nil {
-		return fmt.Errorf("continue: %w", err)
-	}
-	sqlDB, err := gdb.DB()
-	if err != nil {
-		return fmt.Errorf("continue: get sql db: %w", err)
-	}
-	defer func() { _ = sqlDB.Close() }()
-
-	providerUsed := strings.TrimSpace(meta.ProviderUsed)
-	if providerUsed == "" {
-		providerUsed = "gemini"
-	}
-	var providerErrPtr *string
-	if strings.TrimSpace(meta.ProviderError) != "" {
-		v := meta.ProviderError
-		providerErrPtr = &v
+func readinessGate(out io.Writer, doc readinessDocument, opts continueOptions) (bool, error) {
+	if opts.Headless {
+		if opts.Strict {
+			if opts.Docs != nil {
+				if err := emitDocument(opts.Docs, doc); err != nil {
+					return false, err
+				}
+			}
+			return false, withExitCode(exitNotReady, fmt.Errorf("continue: project is not ready for the next evolution"))
+		}
+		tui.Hint(out, "Not ready. Generating the evolution anyway; pass --strict to stop here.")
+		return true, nil
 	}
-
-	row := pmodels.ProjectEvolution{
-		ID:            uuid.New(),
-		ProjectID:     projectID,
-		RawAIOutput:   rawJSON,
-		ProviderUsed:  providerUsed,
-		FallbackUsed:  meta.FallbackUsed,
-		ProviderError: providerErrPtr,
-		LatencyMS:     meta.LatencyMS,
-		CreatedAt:     time.Now(),
+	if opts.Strict {
+		tui.Hint(out, "Resolve the blocking gaps before the next evolution.")
+		return false, nil
 	}
-	if err := gdb.Create(&row).Error; err != nil {
-		return fmt.Errorf("continue: save evolution: %w", err)
+	selection, err := tui.SelectOption(os.Stdin, out, "Choose next action.", []tui.Option{
+		{ID: "anyway", Label: "Generate evolution anyway"},
+		{ID: "back", Label: "Back"},
+	})
+	if err != nil {
+		return false, err
 	}
-	return nil
+	return selection.ID == "anyway", nil
 }
 
-func runViewSavedProjects(ctx context.Context, out io.Writer) error {
+func runViewSavedProjects(ctx context.Context, store persistence.Store, out io.Writer) error {
 	loadSpin := tui.StartSpinner(ctx, out, "Loading saved projects")
-	projects, err := loadRecentProjects(ctx)
+	projects, err := loadRecentProjects(ctx, store)
 	loadSpin.Stop()
 	if err != nil {
 		return err
@@ -837,22 +1070,42 @@ func runViewSavedProjects(ctx context.Context, out io.Writer) error {
 
 	printIdea(out, idea, model.ProjectInput{})
 
+	quality, err := savedQualityScoresDocument(*selected)
+	if err != nil {
+		return fmt.Errorf("view: %w", err)
+	}
+	printQualityScores(out, quality)
+
+	if decideSimilarity(selected.SimilarityScore) != project.SimilarityOK {
+		similarities, err := store.ListSimilarities(ctx, selected.ID)
+		if err != nil {
+			return fmt.Errorf("view: %w", err)
+		}
+		if len(similarities) > 0 {
+			printSimilarityBreakdown(out, savedSimilarityBreakdownDocument(similarities[0]))
+		}
+	}
+
 	evoSpin := tui.StartSpinner(ctx, out, "Loading evolutions")
-	evolutions, err := loadProjectEvolutions(ctx, selected.ID)
+	evolutions, err := loadProjectEvolutions(ctx, store, selected.ID)
 	evoSpin.Stop()
 	if err != nil {
 		return err
 	}
 
+	reviews, err := loadReadinessReviews(ctx, store, selected.ID)
+	if err != nil {
+		return err
+	}
+	printReadinessHistory(out, reviews)
+
 	tui.Heading(out, "Saved Evolutions")
 	for i := range evolutions {
-		var evo ai.ProjectEvolution
-		if err := json.Unmarshal([]byte(evolutions[i].RawAIOutput), &evo); err != nil {
-			return fmt.Errorf("view: parse saved evolution: %w", err)
-		}
 		fmt.Fprintln(out, "")
 		fmt.Fprintf(out, "Evolution #%d\n", i+1)
-		printEvolution(out, evo)
+		if err := printSavedEvolution(out, evolutions[i]); err != nil {
+			return err
+		}
 	}
 
 	for {
@@ -868,13 +1121,11 @@ func runViewSavedProjects(ctx context.Context, out io.Writer) error {
 				fmt.Fprintln(&buf, "")
 				fmt.Fprintln(&buf, "Saved Evolutions")
 				for i := range evolutions {
-					var evo ai.ProjectEvolution
-					if err := json.Unmarshal([]byte(evolutions[i].RawAIOutput), &evo); err != nil {
-						return fmt.Errorf("view: parse saved evolution: %w", err)
-					}
 					fmt.Fprintln(&buf, "")
 					fmt.Fprintf(&buf, "Evolution #%d\n", i+1)
-					printEvolution(&buf, evo)
+					if err := printSavedEvolution(&buf, evolutions[i]); err != nil {
+						return err
+					}
 					fmt.Fprintln(&buf, "")
 					fmt.Fprintln(&buf, "----")
 					fmt.Fprintln(&buf, "Evolution Raw JSON")
@@ -896,6 +1147,45 @@ func runViewSavedProjects(ctx context.Context, out io.Writer) error {
 	}
 }
 
+func runListSavedProjects(ctx context.Context, store persistence.Store, out io.Writer, docs io.Writer) error {
+	loadSpin := tui.StartSpinner(ctx, out, "Loading saved projects")
+	projects, err := loadRecentProjects(ctx, store)
+	loadSpin.Stop()
+	if err != nil {
+		return err
+	}
+
+	result := make([]projectDocument, 0, len(projects))
+	for i := range projects {
+		var idea ai.ProjectIdea
+		if err := json.Unmarshal([]byte(projects[i].RawAIOutput), &idea); err != nil {
+			return fmt.Errorf("view: parse saved raw_ai_output: %w", err)
+		}
+		evolutions, err := loadProjectEvolutions(ctx, store, projects[i].ID)
+		if err != nil {
+			return err
+		}
+		reviews, err := loadReadinessReviews(ctx, store, projects[i].ID)
+		if err != nil {
+			return err
+		}
+		scopes, err := store.ListScopeVersions(ctx, projects[i].ID)
+		if err != nil {
+			return fmt.Errorf("view: %w", err)
+		}
+		similarities, err := store.ListSimilarities(ctx, projects[i].ID)
+		if err != nil {
+			return fmt.Errorf("view: %w", err)
+		}
+		doc, err := savedProjectDocument(projects[i], idea, evolutions, reviews, scopes, similarities)
+		if err != nil {
+			return err
+		}
+		result = append(result, doc)
+	}
+	return emitDocuments(docs, result)
+}
+
 func buildSavedProjectEntries(projects []pmodels.Project) []tui.SelectEntry {
 	type group struct {
 		Title string
@@ -932,6 +1222,9 @@ func buildSavedProjectEntries(projects []pmodels.Project) []tui.SelectEntry {
 		})
 		for _, p := range g.Items {
 			label := fmt.Sprintf("%s (%s, %s)", p.ProjectOverview, p.Complexity, p.Duration)
+			if p.QualityScore != nil {
+				label += fmt.Sprintf(" · quality %.2f", *p.QualityScore)
+			}
 			entries = append(entries, tui.SelectEntry{
 				ID:         p.ID.String(),
 				Label:      "  ▸ " + label,
@@ -979,21 +1272,11 @@ func savedProjectGroupTitle(appType string) string {
 	return "Others"
 }
 
-func loadProjectEvolutions(ctx context.Context, projectID uuid.UUID) ([]pmodels.ProjectEvolution, error) {
-	gdb, err := db.Connect(ctx)
+func loadProjectEvolutions(ctx context.Context, store persistence.Store, projectID uuid.UUID) ([]pmodels.ProjectEvolution, error) {
+	rows, err := store.ListEvolutions(ctx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("view: %w", err)
 	}
-	sqlDB, err := gdb.DB()
-	if err != nil {
-		return nil, fmt.Errorf("view: get sql db: %w", err)
-	}
-	defer func() { _ = sqlDB.Close() }()
-
-	var rows []pmodels.ProjectEvolution
-	if err := gdb.Where("project_id = ?", projectID).Order("created_at asc").Find(&rows).Error; err != nil {
-		return nil, fmt.Errorf("view: load evolutions: %w", err)
-	}
 	return rows, nil
 }
 
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"quibit/internal/model"
+	tuiinput "quibit/internal/tui/input"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	genAppType    string
+	genStack      []string
+	genDatabase   []string
+	genComplexity string
+	genGoal       string
+	genTimeframe  string
+	genKind       string
+	genIdea       string
+	genYes        bool
+	genEnsemble   bool
+)
+
+var generateInputFlags = []string{
+	"app-type",
+	"stack",
+	"db",
+	"complexity",
+	"goal",
+	"timeframe",
+	"kind",
+	"idea",
+	"yes",
+}
+
+func init() {
+	f := generateCmd.Flags()
+	f.StringVar(&genAppType, "app-type", "", "Application type (web, cli, mobile, desktop, ml, backend-api, ...)")
+	f.StringSliceVar(&genStack, "stack", nil, "Tech stack constraints (comma-separated)")
+	f.StringSliceVar(&genDatabase, "db", nil, "Database preference(s) (comma-separated, or none)")
+	f.StringVar(&genComplexity, "complexity", "", "Complexity level (beginner|intermediate|advanced)")
+	f.StringVar(&genGoal, "goal", "", "Project goal")
+	f.StringVar(&genTimeframe, "timeframe", "", "Estimated timeframe (e.g. 2-4 weeks)")
+	f.StringVar(&genKind, "kind", "", "Optional project category (lms, crm, fintech, ...)")
+	f.StringVar(&genIdea, "idea", "", "Generate from your own idea / problem (use - to read from stdin)")
+	f.BoolVar(&genYes, "yes", false, "Accept and save the result without prompting")
+	f.BoolVar(&genEnsemble, "ensemble", false, "Ask every configured provider at once and keep the best idea")
+}
+
+func generateHeadlessRequested(cmd *cobra.Command) bool {
+	if cmd == nil || cmd.Name() != "generate" {
+		return false
+	}
+	for _, name := range generateInputFlags {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func projectInputFromFlags(stdin io.Reader) (model.ProjectInput, error) {
+	idea := strings.TrimSpace(genIdea)
+	if idea == "-" {
+		b, err := io.ReadAll(stdin)
+		if err != nil {
+			return model.ProjectInput{}, fmt.Errorf("generate: read idea from stdin: %w", err)
+		}
+		idea = strings.TrimSpace(string(b))
+		if idea == "" {
+			return model.ProjectInput{}, withExitCode(exitUsage, fmt.Errorf("generate: --idea - requires a non-empty idea on stdin"))
+		}
+	}
+
+	complexity := strings.ToLower(strings.TrimSpace(genComplexity))
+	if complexity == "" {
+		complexity = tuiinput.ComplexityPrompt.Default.Value
+	}
+	switch complexity {
+	case "beginner", "intermediate", "advanced":
+	default:
+		return model.ProjectInput{}, withExitCode(exitUsage, fmt.Errorf("generate: --complexity must be beginner|intermediate|advanced"))
+	}
+
+	in := model.ProjectInput{
+		UserIdea:    idea,
+		AppType:     flagOrDefault(genAppType, tuiinput.ApplicationTypePrompt.Default.Value),
+		ProjectKind: strings.TrimSpace(genKind),
+		Complexity:  complexity,
+		TechStack:   cleanFlagList(genStack),
+		Database:    cleanFlagList(genDatabase),
+		Goal:        flagOrDefault(genGoal, tuiinput.ProjectGoalPrompt.Default.Value),
+		Timeframe:   flagOrDefault(genTimeframe, tuiinput.EstimatedTimeframePrompt.Default.Value),
+	}
+	if in.UserIdea == "" && len(in.Database) == 0 {
+		in.Database = []string{tuiinput.DatabasePrompt.Default.Value}
+	}
+	return in, nil
+}
+
+func flagOrDefault(v string, def string) string {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+func cleanFlagList(items []string) []string {
+	out := make([]string, 0, len(items))
+	for _, v := range items {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"quibit/internal/ai"
+	"quibit/internal/model"
+	"quibit/internal/persistence"
+)
+
+// useMemoryStore points every command at one in-memory store for the test.
+func useMemoryStore(t *testing.T) *persistence.MemoryStore {
+	t.Helper()
+	store := persistence.NewMemoryStore()
+	prev := openStore
+	openStore = func(context.Context) (persistence.Store, error) { return store, nil }
+	t.Cleanup(func() {
+		openStore = prev
+		outputFormat = outputText
+	})
+	return store
+}
+
+// useReplayFixtures answers each prompt with the contents of its fixture.
+func useReplayFixtures(t *testing.T, fixtures map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	for prompt, fixture := range fixtures {
+		data, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, ai.PromptFixtureKey(prompt)+".txt"), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("QUIBIT_PROVIDERS", "replay")
+	t.Setenv("QUIBIT_REPLAY_DIR", dir)
+	t.Setenv("QUIBIT_REPLAY_MODE", "")
+	t.Setenv("QUIBIT_JUDGE", "")
+	t.Setenv("QUIBIT_JUDGE_PROVIDERS", "")
+	t.Setenv("QUIBIT_QUALITY_RULES", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+}
+
+func executeCommand(t *testing.T, args ...string) ([]byte, error) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&errOut)
+	rootCmd.SetArgs(args)
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		rootCmd.SetArgs(nil)
+		for _, c := range rootCmd.Commands() {
+			resetFlags(c)
+		}
+	})
+	err := rootCmd.ExecuteContext(context.Background())
+	return out.Bytes(), err
+}
+
+// resetFlags puts c's flags back to their defaults so one test's flags do not
+// leak into the next command run.
+func resetFlags(c *cobra.Command) {
+	c.Flags().VisitAll(func(f *pflag.Flag) {
+		if v, ok := f.Value.(pflag.SliceValue); ok {
+			_ = v.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+func runCommand(t *testing.T, args ...string) []byte {
+	t.Helper()
+	out, err := executeCommand(t, args...)
+	if err != nil {
+		t.Fatalf("quibit %v: %v", args, err)
+	}
+	return out
+}
+
+func TestGenerateSavesToStore(t *testing.T) {
+	store := useMemoryStore(t)
+	input := model.ProjectInput{
+		AppType:    "cli",
+		Complexity: "intermediate",
+		TechStack:  []string{},
+		Database:   []string{"SQLite"},
+		Goal:       "Portfolio",
+		Timeframe:  "2-4 weeks",
+	}
+	useReplayFixtures(t, map[string]string{ai.BuildProjectIdeaPrompt(input): "../internal/ai/testdata/project_idea.json"})
+
+	var generated projectDocument
+	out := runCommand(t, "generate", "--app-type", "cli", "--complexity", "intermediate", "--db", "SQLite",
+		"--goal", "Portfolio", "--timeframe", "2-4 weeks", "--yes", "--output", "json")
+	if err := json.Unmarshal(out, &generated); err != nil {
+		t.Fatalf("decode generate output: %v\n%s", err, out)
+	}
+	if !generated.Saved || generated.ProjectID == "" {
+		t.Fatalf("generate: saved=%v project_id=%q, want a saved project", generated.Saved, generated.ProjectID)
+	}
+	id, err := uuid.Parse(generated.ProjectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.GetProjectDNA(context.Background(), id); err != nil {
+		t.Fatalf("project dna: %v", err)
+	}
+
+	var listed []projectDocument
+	out = runCommand(t, "browse", "--output", "json")
+	if err := json.Unmarshal(out, &listed); err != nil {
+		t.Fatalf("decode browse output: %v\n%s", err, out)
+	}
+	if len(listed) != 1 || listed[0].ProjectID != generated.ProjectID {
+		t.Fatalf("browse listed %d projects, want only %s", len(listed), generated.ProjectID)
+	}
+	if got, want := listed[0].Idea.Project.Name, generated.Idea.Project.Name; got != want {
+		t.Errorf("browse name = %q, want %q", got, want)
+	}
+
+	raw, err := json.Marshal(generated.Idea)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = saveGeneratedProject(context.Background(), store, input, generated.Idea, string(raw), ai.AIResult{}, nil, 0, nil)
+	if !errors.Is(err, persistence.ErrDuplicateDNA) {
+		t.Fatalf("saving the same idea again: err = %v, want ErrDuplicateDNA", err)
+	}
+}
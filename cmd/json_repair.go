@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"quibit/internal/ai"
+	"quibit/internal/persistence"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/tui"
+)
+
+const jsonRepairFlushTimeout = 5 * time.Second
+
+var qualityRepairsCmd = &cobra.Command{
+	Use:   "repairs",
+	Short: "Show how often model answers needed JSON repair, per repair path.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		store, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStore(store)
+
+		rows, err := store.ListJSONRepairStats(ctx)
+		if err != nil {
+			return err
+		}
+		doc := newJSONRepairStatsDocument(rows)
+		out := cmd.OutOrStdout()
+		if structuredOutput() {
+			return emitDocument(out, doc)
+		}
+		printJSONRepairStats(out, doc)
+		return nil
+	},
+}
+
+// flushJSONRepairCounts adds the repair counters collected so far to the
+// stored totals. It is best effort: a failed write never fails the command.
+// The timeout is separate from the command's context so an interrupted run
+// still records the answers it got.
+func flushJSONRepairCounts(store persistence.Store) {
+	counts := ai.TakeJSONRepairCounts()
+	if len(counts) == 0 {
+		return
+	}
+	byPath := make(map[string]int64, len(counts))
+	for path, n := range counts {
+		byPath[string(path)] = n
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), jsonRepairFlushTimeout)
+	defer cancel()
+	_ = store.AddJSONRepairCounts(ctx, byPath)
+}
+
+type jsonRepairStatsDocument struct {
+	Type  string                   `json:"type"`
+	Total int64                    `json:"total"`
+	Paths []jsonRepairPathDocument `json:"paths"`
+}
+
+type jsonRepairPathDocument struct {
+	Path      string    `json:"path"`
+	Count     int64     `json:"count"`
+	Share     float64   `json:"share"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newJSONRepairStatsDocument(rows []pmodels.JSONRepairStat) jsonRepairStatsDocument {
+	doc := jsonRepairStatsDocument{Type: "json_repair_stats", Paths: make([]jsonRepairPathDocument, 0, len(rows))}
+	for _, r := range rows {
+		doc.Total += r.Count
+	}
+	for _, r := range rows {
+		share := 0.0
+		if doc.Total > 0 {
+			share = float64(r.Count) / float64(doc.Total)
+		}
+		doc.Paths = append(doc.Paths, jsonRepairPathDocument{Path: r.Path, Count: r.Count, Share: share, UpdatedAt: r.UpdatedAt})
+	}
+	return doc
+}
+
+func printJSONRepairStats(out io.Writer, doc jsonRepairStatsDocument) {
+	tui.Heading(out, "JSON Repair")
+	if doc.Total == 0 {
+		tui.Hint(out, "No JSON answers recorded yet.")
+		return
+	}
+	for _, p := range doc.Paths {
+		fmt.Fprintf(out, "%-10s %6d  %5.1f%%\n", p.Path, p.Count, p.Share*100)
+	}
+	tui.Hint(out, fmt.Sprintf("%d JSON answers recorded.", doc.Total))
+}
+
+func init() {
+	qualityCmd.AddCommand(qualityRepairsCmd)
+}
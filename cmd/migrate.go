@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"quibit/internal/persistence"
+	"quibit/internal/tui"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateDownSteps int
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database schema migrations.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMigrateUp(cmd.Context(), cmd.OutOrStdout())
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Revert the most recently applied migrations.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		out := cmd.OutOrStdout()
+		store, err := persistence.Open(ctx)
+		if err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+		defer closeStore(store)
+
+		spin := tui.StartSpinner(ctx, out, "Reverting database migrations")
+		reverted, err := persistence.MigrateDown(ctx, store.DB(), migrateDownSteps)
+		spin.Stop()
+		for _, m := range reverted {
+			tui.Done(out, fmt.Sprintf("Reverted %04d_%s", m.Version, m.Name))
+		}
+		if err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+		if len(reverted) == 0 {
+			tui.Context(out, "No applied migrations to revert.")
+		}
+		return nil
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		out := cmd.OutOrStdout()
+		store, err := persistence.Open(ctx)
+		if err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+		defer closeStore(store)
+
+		states, err := persistence.MigrationStatus(ctx, store.DB())
+		if err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+		for _, st := range states {
+			status := "pending"
+			if st.AppliedAt != nil {
+				status = "applied " + st.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%04d_%-28s %s\n", st.Version, st.Name, status)
+		}
+		return nil
+	},
+}
+
+func runMigrateUp(ctx context.Context, out io.Writer) error {
+	store, err := persistence.Open(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	defer closeStore(store)
+
+	spin := tui.StartSpinner(ctx, out, "Running database migrations")
+	applied, err := persistence.MigrateUp(ctx, store.DB())
+	spin.Stop()
+	for _, m := range applied {
+		tui.Done(out, fmt.Sprintf("Applied %04d_%s", m.Version, m.Name))
+	}
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	tui.Done(out, "Database migrations completed")
+	return nil
+}
+
+func isMigrateCmd(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == migrateCmd {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	migrateDownCmd.Flags().IntVar(&migrateDownSteps, "steps", 1, "Number of migrations to revert")
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd)
+}
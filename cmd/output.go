@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quibit/internal/ai"
+	"quibit/internal/model"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/project"
+)
+
+const (
+	outputText   = "text"
+	outputJSON   = "json"
+	outputNDJSON = "ndjson"
+)
+
+var outputFormat string
+
+func validateOutputFormat() error {
+	switch normalizedOutputFormat() {
+	case outputText, outputJSON, outputNDJSON:
+		return nil
+	default:
+		return withExitCode(exitUsage, fmt.Errorf("--output must be text|json|ndjson"))
+	}
+}
+
+func normalizedOutputFormat() string {
+	v := strings.ToLower(strings.TrimSpace(outputFormat))
+	if v == "" {
+		return outputText
+	}
+	return v
+}
+
+func structuredOutput() bool {
+	return normalizedOutputFormat() != outputText
+}
+
+type projectDocument struct {
+	Type       string                 `json:"type"`
+	ProjectID  string                 `json:"project_id,omitempty"`
+	Saved      bool                   `json:"saved"`
+	CreatedAt  *time.Time             `json:"created_at,omitempty"`
+	Input      *inputDocument         `json:"input,omitempty"`
+	Idea       ai.ProjectIdea         `json:"idea"`
+	AI         aiMetaDocument         `json:"ai"`
+	Similarity *similarityDocument    `json:"similarity,omitempty"`
+	Quality    *qualityScoresDocument `json:"quality,omitempty"`
+	Evolutions []evolutionDocument    `json:"evolutions,omitempty"`
+	Scopes     []scopeVersionDocument `json:"scope_versions,omitempty"`
+	Readiness  []readinessDocument    `json:"readiness_reviews,omitempty"`
+}
+
+type inputDocument struct {
+	UserIdea    string   `json:"user_idea,omitempty"`
+	AppType     string   `json:"app_type"`
+	ProjectKind string   `json:"project_kind,omitempty"`
+	Complexity  string   `json:"complexity"`
+	TechStack   []string `json:"tech_stack"`
+	Database    []string `json:"database"`
+	Goal        string   `json:"goal"`
+	Timeframe   string   `json:"timeframe"`
+}
+
+type aiMetaDocument struct {
+	Provider      string `json:"provider"`
+	FallbackUsed  bool   `json:"fallback_used"`
+	ProviderError string `json:"provider_error,omitempty"`
+	LatencyMS     int64  `json:"latency_ms"`
+	RetryReason   string `json:"retry_reason,omitempty"`
+	JSONRepair    string `json:"json_repair,omitempty"`
+}
+
+type similarityDocument struct {
+	Score     float64                      `json:"score"`
+	Decision  string                       `json:"decision"`
+	Breakdown *similarityBreakdownDocument `json:"breakdown,omitempty"`
+}
+
+type evolutionDocument struct {
+	Type         string                 `json:"type"`
+	EvolutionID  string                 `json:"evolution_id,omitempty"`
+	ProjectID    string                 `json:"project_id"`
+	Saved        bool                   `json:"saved"`
+	CreatedAt    *time.Time             `json:"created_at,omitempty"`
+	Contract     string                 `json:"contract"`
+	Evolution    *ai.ProjectEvolution   `json:"evolution,omitempty"`
+	NextPhase    *ai.NextPhaseEvolution `json:"next_phase,omitempty"`
+	ScopeVersion int                    `json:"scope_version,omitempty"`
+	AI           aiMetaDocument         `json:"ai"`
+	Readiness    *readinessDocument     `json:"readiness,omitempty"`
+}
+
+type scopeVersionDocument struct {
+	Version     int       `json:"version"`
+	EvolutionID string    `json:"evolution_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	MustHave    []string  `json:"must_have"`
+	OutOfScope  []string  `json:"out_of_scope"`
+}
+
+type readinessDocument struct {
+	Type          string         `json:"type"`
+	ReviewID      string         `json:"review_id"`
+	ProjectID     string         `json:"project_id"`
+	CreatedAt     time.Time      `json:"created_at"`
+	Verdict       string         `json:"verdict"`
+	Built         []string       `json:"built"`
+	BlockingGaps  []string       `json:"blocking_gaps"`
+	Prerequisites []string       `json:"prerequisites"`
+	RisksIfForced []string       `json:"risks_if_forced"`
+	AI            aiMetaDocument `json:"ai"`
+}
+
+func emitDocument(w io.Writer, doc any) error {
+	var b []byte
+	var err error
+	if normalizedOutputFormat() == outputNDJSON {
+		b, err = json.Marshal(doc)
+	} else {
+		b, err = json.MarshalIndent(doc, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("output: marshal document: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
+
+func emitDocuments[T any](w io.Writer, docs []T) error {
+	if normalizedOutputFormat() == outputNDJSON {
+		for i := range docs {
+			if err := emitDocument(w, docs[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if docs == nil {
+		docs = []T{}
+	}
+	return emitDocument(w, docs)
+}
+
+func newInputDocument(in model.ProjectInput) *inputDocument {
+	stack := in.TechStack
+	if stack == nil {
+		stack = []string{}
+	}
+	dbs := in.Database
+	if dbs == nil {
+		dbs = []string{}
+	}
+	return &inputDocument{
+		UserIdea:    in.UserIdea,
+		AppType:     in.AppType,
+		ProjectKind: in.ProjectKind,
+		Complexity:  in.Complexity,
+		TechStack:   stack,
+		Database:    dbs,
+		Goal:        in.Goal,
+		Timeframe:   in.Timeframe,
+	}
+}
+
+func newAIMetaDocument(meta ai.AIResult, retryReason *ai.RetryReason) aiMetaDocument {
+	doc := aiMetaDocument{
+		Provider:      strings.TrimSpace(meta.ProviderUsed),
+		FallbackUsed:  meta.FallbackUsed,
+		ProviderError: strings.TrimSpace(meta.ProviderError),
+		LatencyMS:     meta.LatencyMS,
+		JSONRepair:    string(meta.JSONRepair),
+	}
+	if retryReason != nil {
+		doc.RetryReason = string(*retryReason)
+	}
+	return doc
+}
+
+func similarityDecisionName(d project.SimilarityDecision) string {
+	switch d {
+	case project.SimilarityRegenerate:
+		return "regenerate"
+	case project.SimilarityBlock:
+		return "block"
+	default:
+		return "ok"
+	}
+}
+
+func savedProjectDocument(row pmodels.Project, idea ai.ProjectIdea, evolutions []pmodels.ProjectEvolution, reviews []pmodels.ProjectReadinessReview, scopes []pmodels.ProjectScopeVersion, similarities []pmodels.ProjectSimilarity) (projectDocument, error) {
+	createdAt := row.CreatedAt
+	doc := projectDocument{
+		Type:      "project",
+		ProjectID: row.ID.String(),
+		Saved:     true,
+		CreatedAt: &createdAt,
+		Idea:      idea,
+		AI: aiMetaDocument{
+			Provider:      row.ProviderUsed,
+			FallbackUsed:  row.FallbackUsed,
+			ProviderError: derefString(row.ProviderError),
+			LatencyMS:     row.LatencyMS,
+			RetryReason:   derefString(row.RetryReason),
+		},
+		Similarity: &similarityDocument{
+			Score:    row.SimilarityScore,
+			Decision: similarityDecisionName(decideSimilarity(row.SimilarityScore)),
+		},
+	}
+	if len(similarities) > 0 {
+		doc.Similarity.Breakdown = savedSimilarityBreakdownDocument(similarities[0])
+	}
+	quality, err := savedQualityScoresDocument(row)
+	if err != nil {
+		return projectDocument{}, err
+	}
+	doc.Quality = quality
+	scopeByEvolution := map[uuid.UUID]int{}
+	for i := range scopes {
+		scope, err := savedScopeVersionDocument(scopes[i])
+		if err != nil {
+			return projectDocument{}, err
+		}
+		doc.Scopes = append(doc.Scopes, scope)
+		if scopes[i].EvolutionID != nil {
+			scopeByEvolution[*scopes[i].EvolutionID] = scopes[i].Version
+		}
+	}
+	for i := range evolutions {
+		evo, err := savedEvolutionDocument(evolutions[i])
+		if err != nil {
+			return projectDocument{}, err
+		}
+		evo.ScopeVersion = scopeByEvolution[evolutions[i].ID]
+		doc.Evolutions = append(doc.Evolutions, evo)
+	}
+	for i := range reviews {
+		review, err := savedReadinessDocument(reviews[i])
+		if err != nil {
+			return projectDocument{}, err
+		}
+		doc.Readiness = append(doc.Readiness, review)
+	}
+	return doc, nil
+}
+
+func savedEvolutionDocument(row pmodels.ProjectEvolution) (evolutionDocument, error) {
+	evo, err := ai.DecodeSavedEvolution(row.Contract, row.RawAIOutput)
+	if err != nil {
+		return evolutionDocument{}, fmt.Errorf("output: parse saved evolution: %w", err)
+	}
+	createdAt := row.CreatedAt
+	return evolutionDocument{
+		Type:        "evolution",
+		EvolutionID: row.ID.String(),
+		ProjectID:   row.ProjectID.String(),
+		Saved:       true,
+		CreatedAt:   &createdAt,
+		Contract:    evo.Contract,
+		Evolution:   evo.Project,
+		NextPhase:   evo.NextPhase,
+		AI: aiMetaDocument{
+			Provider:      row.ProviderUsed,
+			FallbackUsed:  row.FallbackUsed,
+			ProviderError: derefString(row.ProviderError),
+			LatencyMS:     row.LatencyMS,
+		},
+	}, nil
+}
+
+func savedScopeVersionDocument(row pmodels.ProjectScopeVersion) (scopeVersionDocument, error) {
+	mustHave, err := parseStringArray(row.MustHaveJSON)
+	if err != nil {
+		return scopeVersionDocument{}, fmt.Errorf("output: parse saved scope version: %w", err)
+	}
+	outOfScope, err := parseStringArray(row.OutOfScopeJSON)
+	if err != nil {
+		return scopeVersionDocument{}, fmt.Errorf("output: parse saved scope version: %w", err)
+	}
+	doc := scopeVersionDocument{
+		Version:    row.Version,
+		CreatedAt:  row.CreatedAt,
+		MustHave:   mustHave,
+		OutOfScope: outOfScope,
+	}
+	if row.EvolutionID != nil {
+		doc.EvolutionID = row.EvolutionID.String()
+	}
+	return doc, nil
+}
+
+func newReadinessDocument(row pmodels.ProjectReadinessReview, review ai.EvolutionReadiness, built []string) readinessDocument {
+	if built == nil {
+		built = []string{}
+	}
+	return readinessDocument{
+		Type:          "readiness",
+		ReviewID:      row.ID.String(),
+		ProjectID:     row.ProjectID.String(),
+		CreatedAt:     row.CreatedAt,
+		Verdict:       review.Verdict,
+		Built:         built,
+		BlockingGaps:  review.BlockingGaps,
+		Prerequisites: review.ConcretePrerequisites,
+		RisksIfForced: review.RisksIfForced,
+		AI: aiMetaDocument{
+			Provider:      row.ProviderUsed,
+			FallbackUsed:  row.FallbackUsed,
+			ProviderError: derefString(row.ProviderError),
+			LatencyMS:     row.LatencyMS,
+		},
+	}
+}
+
+func savedReadinessDocument(row pmodels.ProjectReadinessReview) (readinessDocument, error) {
+	var review ai.EvolutionReadiness
+	if err := json.Unmarshal([]byte(row.RawAIOutput), &review); err != nil {
+		return readinessDocument{}, fmt.Errorf("output: parse saved readiness review: %w", err)
+	}
+	var built []string
+	if err := json.Unmarshal([]byte(row.BuiltScopeJSON), &built); err != nil {
+		return readinessDocument{}, fmt.Errorf("output: parse saved built scope: %w", err)
+	}
+	review.Verdict = row.Verdict
+	return newReadinessDocument(row, review, built), nil
+}
+
+func derefString(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
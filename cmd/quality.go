@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"quibit/internal/ai"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/quality"
+	"quibit/internal/tui"
+)
+
+var qualityCheckAll bool
+
+var qualityCmd = &cobra.Command{
+	Use:   "quality",
+	Short: "Inspect the idea quality gate.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var qualityCheckCmd = &cobra.Command{
+	Use:   "check <file.json>",
+	Short: "Run the quality gate on an idea and explain which rules fired.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		idea, err := readQualityIdea(args[0])
+		if err != nil {
+			return withExitCode(exitUsage, err)
+		}
+		rules, err := ai.LoadQualityRules()
+		if err != nil {
+			return err
+		}
+		doc := newQualityDocument(rules, rules.Evaluate(ai.QualityDocument(idea)))
+		out := cmd.OutOrStdout()
+		if structuredOutput() {
+			return emitDocument(out, doc)
+		}
+		printQualityReport(out, doc, qualityCheckAll)
+		return nil
+	},
+}
+
+// readQualityIdea accepts a bare idea ({"project": ...}) or any document that
+// wraps one under "idea", such as `generate --output json` or a JSON export.
+func readQualityIdea(path string) (ai.ProjectIdea, error) {
+	var data []byte
+	var err error
+	if strings.TrimSpace(path) == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return ai.ProjectIdea{}, fmt.Errorf("quality: read %s: %w", path, err)
+	}
+	var wrapped struct {
+		Idea *ai.ProjectIdea `json:"idea"`
+		ai.ProjectIdea
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return ai.ProjectIdea{}, fmt.Errorf("quality: parse %s: %w", path, err)
+	}
+	idea := wrapped.ProjectIdea
+	if wrapped.Idea != nil {
+		idea = *wrapped.Idea
+	}
+	if strings.TrimSpace(idea.Project.Name) == "" {
+		return ai.ProjectIdea{}, fmt.Errorf("quality: %s does not contain a project idea", path)
+	}
+	return idea, nil
+}
+
+type qualityDocument struct {
+	Type     string                `json:"type"`
+	Ruleset  string                `json:"ruleset"`
+	Decision string                `json:"decision"`
+	HardFail bool                  `json:"hard_fail"`
+	Score    float64               `json:"score"`
+	Reasons  []string              `json:"reasons"`
+	Rules    []qualityRuleDocument `json:"rules"`
+}
+
+type qualityRuleDocument struct {
+	ID       string                  `json:"id"`
+	Fired    bool                    `json:"fired"`
+	Decision string                  `json:"decision"`
+	HardFail bool                    `json:"hard_fail"`
+	Weight   float64                 `json:"weight"`
+	Reason   string                  `json:"reason"`
+	Signals  []qualitySignalDocument `json:"signals"`
+}
+
+type qualitySignalDocument struct {
+	Name    string   `json:"name"`
+	Fired   bool     `json:"fired"`
+	Matches []string `json:"matches,omitempty"`
+}
+
+func newQualityDocument(rules *quality.Ruleset, r quality.Report) qualityDocument {
+	doc := qualityDocument{
+		Type:     "quality_check",
+		Ruleset:  rules.Source(),
+		Decision: string(r.Decision),
+		HardFail: r.HardFail,
+		Score:    r.Score,
+		Reasons:  r.Reasons,
+		Rules:    make([]qualityRuleDocument, 0, len(r.Rules)),
+	}
+	if doc.Reasons == nil {
+		doc.Reasons = []string{}
+	}
+	for _, rr := range r.Rules {
+		rd := qualityRuleDocument{
+			ID:       rr.ID,
+			Fired:    rr.Fired,
+			Decision: string(rr.Decision),
+			HardFail: rr.HardFail,
+			Weight:   rr.Weight,
+			Reason:   rr.Reason,
+			Signals:  make([]qualitySignalDocument, 0, len(rr.Signals)),
+		}
+		for _, s := range rr.Signals {
+			rd.Signals = append(rd.Signals, qualitySignalDocument{Name: s.Name, Fired: s.Fired, Matches: s.Matches})
+		}
+		doc.Rules = append(doc.Rules, rd)
+	}
+	return doc
+}
+
+func printQualityReport(out io.Writer, doc qualityDocument, all bool) {
+	tui.Heading(out, "Quality Gate")
+	tui.Hint(out, fmt.Sprintf("ruleset: %s", doc.Ruleset))
+	verdict := doc.Decision
+	if doc.HardFail {
+		verdict += " (hard fail)"
+	}
+	fmt.Fprintf(out, "Decision: %s\n", verdict)
+	fmt.Fprintf(out, "Score:    %.2f\n", doc.Score)
+
+	fired := 0
+	for _, r := range doc.Rules {
+		if r.Fired {
+			fired++
+		}
+	}
+	if fired == 0 && !all {
+		fmt.Fprintln(out, "")
+		tui.Done(out, "No rules fired.")
+		return
+	}
+	fmt.Fprintln(out, "")
+	for _, r := range doc.Rules {
+		if !r.Fired && !all {
+			continue
+		}
+		mark := "pass"
+		if r.Fired {
+			mark = "FIRED"
+		}
+		fmt.Fprintf(out, "[%s] %s  %s · weight %.2f\n", mark, r.ID, r.Decision, r.Weight)
+		if r.Fired {
+			fmt.Fprintf(out, "   %s\n", r.Reason)
+		}
+		for _, s := range r.Signals {
+			state := "no"
+			if s.Fired {
+				state = "yes"
+			}
+			line := fmt.Sprintf("   %s: %s", s.Name, state)
+			if len(s.Matches) > 0 {
+				line += " (" + strings.Join(s.Matches, ", ") + ")"
+			}
+			fmt.Fprintln(out, line)
+		}
+	}
+}
+
+// qualityScoresDocument is the quality gate result stored with a project:
+// the combined score, the ruleset score and, when the judge ran, its rubric.
+type qualityScoresDocument struct {
+	Score      float64              `json:"score"`
+	Heuristic  float64              `json:"heuristic"`
+	Judge      *float64             `json:"judge,omitempty"`
+	Judgement  *ai.QualityJudgement `json:"judgement,omitempty"`
+	JudgeError string               `json:"judge_error,omitempty"`
+}
+
+func newQualityScoresDocument(q *ai.QualityScores) *qualityScoresDocument {
+	if q == nil {
+		return nil
+	}
+	doc := &qualityScoresDocument{
+		Score:      q.Combined,
+		Heuristic:  q.Heuristic,
+		Judgement:  q.Judge,
+		JudgeError: q.JudgeError,
+	}
+	if q.Judge != nil {
+		score := q.Judge.Score()
+		doc.Judge = &score
+	}
+	return doc
+}
+
+func applyQualityScores(row *pmodels.Project, q *ai.QualityScores) error {
+	if q == nil {
+		return nil
+	}
+	combined, heuristic := q.Combined, q.Heuristic
+	row.QualityScore = &combined
+	row.HeuristicQualityScore = &heuristic
+	if q.Judge != nil {
+		score := q.Judge.Score()
+		b, err := json.Marshal(q.Judge)
+		if err != nil {
+			return fmt.Errorf("marshal quality judgement: %w", err)
+		}
+		judgement := string(b)
+		row.JudgeQualityScore = &score
+		row.QualityJudgement = &judgement
+	}
+	return nil
+}
+
+func savedQualityScoresDocument(row pmodels.Project) (*qualityScoresDocument, error) {
+	if row.QualityScore == nil {
+		return nil, nil
+	}
+	doc := &qualityScoresDocument{Score: *row.QualityScore, Judge: row.JudgeQualityScore}
+	if row.HeuristicQualityScore != nil {
+		doc.Heuristic = *row.HeuristicQualityScore
+	}
+	if row.QualityJudgement != nil && strings.TrimSpace(*row.QualityJudgement) != "" {
+		var j ai.QualityJudgement
+		if err := json.Unmarshal([]byte(*row.QualityJudgement), &j); err != nil {
+			return nil, fmt.Errorf("parse saved quality judgement: %w", err)
+		}
+		doc.Judgement = &j
+	}
+	return doc, nil
+}
+
+func printQualityScores(out io.Writer, doc *qualityScoresDocument) {
+	if doc == nil {
+		return
+	}
+	tui.Heading(out, "Quality")
+	line := fmt.Sprintf("Score %.2f  heuristic %.2f", doc.Score, doc.Heuristic)
+	if doc.Judge != nil {
+		line += fmt.Sprintf(" · judge %.2f", *doc.Judge)
+	}
+	fmt.Fprintln(out, line)
+	if j := doc.Judgement; j != nil {
+		fmt.Fprintf(out, "  depth %.0f · differentiation %.0f · scope %.0f · interviewability %.0f (of 10)\n",
+			j.TechnicalDepth, j.Differentiation, j.ScopeRealism, j.Interviewability)
+		if j.Rationale != "" {
+			fmt.Fprintf(out, "  %s\n", j.Rationale)
+		}
+	}
+	if doc.JudgeError != "" {
+		tui.Hint(out, "Judge unavailable: "+doc.JudgeError)
+	}
+}
+
+func init() {
+	qualityCheckCmd.Flags().BoolVar(&qualityCheckAll, "all", false, "Show every rule, not only the ones that fired")
+	qualityCmd.AddCommand(qualityCheckCmd)
+}
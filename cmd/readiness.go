@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quibit/internal/ai"
+	"quibit/internal/persistence"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/tui"
+	tuiinput "quibit/internal/tui/input"
+)
+
+func runReadinessReview(ctx context.Context, store persistence.Store, out io.Writer, selected *pmodels.Project, mvp []string, stack []string, opts continueOptions) (readinessDocument, error) {
+	built := opts.Built
+	if built == nil {
+		var err error
+		built, err = tuiinput.CollectBuiltScope(os.Stdin, out, mvp)
+		if err != nil {
+			return readinessDocument{}, withExitCode(exitUsage, fmt.Errorf("continue: %w", err))
+		}
+	}
+
+	proposed, err := proposedEvolution(ctx, store, selected)
+	if err != nil {
+		return readinessDocument{}, err
+	}
+
+	input := ai.EvolutionReadinessPromptInput{
+		CurrentProjectOverview:   selected.ProjectOverview,
+		TechStackAndArchitecture: strings.Join(stack, ", "),
+		BuiltMVPScope:            built,
+		ProposedEvolution:        proposed,
+	}
+
+	spin := tui.StartSpinner(ctx, out, "Reviewing readiness")
+	review, rawJSON, meta, err := ai.ReviewEvolutionReadinessWithMeta(ctx, input)
+	spin.Stop()
+	if err != nil {
+		return readinessDocument{}, classifyGenerateError(fmt.Errorf("continue: %w", err))
+	}
+
+	saveSpin := tui.StartSpinner(ctx, out, "Saving readiness review")
+	row, err := saveReadinessReview(ctx, store, selected.ID, review, built, rawJSON, meta)
+	saveSpin.Stop()
+	if err != nil {
+		return readinessDocument{}, err
+	}
+
+	if opts.Docs == nil {
+		printReadiness(out, review)
+	}
+	return newReadinessDocument(row, review, built), nil
+}
+
+// proposedEvolution is what the review judges: the last accepted evolution,
+// or the step beyond the MVP when none exists yet.
+func proposedEvolution(ctx context.Context, store persistence.Store, selected *pmodels.Project) (string, error) {
+	evolutions, err := store.ListEvolutions(ctx, selected.ID)
+	if err != nil {
+		return "", fmt.Errorf("continue: %w", err)
+	}
+	if n := len(evolutions); n > 0 {
+		evo, err := ai.DecodeSavedEvolution(evolutions[n-1].Contract, evolutions[n-1].RawAIOutput)
+		if err == nil && evo.Overview() != "" {
+			return evo.Overview(), nil
+		}
+	}
+	return "Next evolution phase beyond the MVP toward the goal: " + selected.Goal, nil
+}
+
+func saveReadinessReview(ctx context.Context, store persistence.Store, projectID uuid.UUID, review ai.EvolutionReadiness, built []string, rawJSON string, meta ai.AIResult) (pmodels.ProjectReadinessReview, error) {
+	builtJSON, err := json.Marshal(built)
+	if err != nil {
+		return pmodels.ProjectReadinessReview{}, fmt.Errorf("continue: marshal built scope: %w", err)
+	}
+	providerUsed := strings.TrimSpace(meta.ProviderUsed)
+	if providerUsed == "" {
+		providerUsed = "gemini"
+	}
+	var providerErrPtr *string
+	if strings.TrimSpace(meta.ProviderError) != "" {
+		v := meta.ProviderError
+		providerErrPtr = &v
+	}
+
+	row := pmodels.ProjectReadinessReview{
+		ID:             uuid.New(),
+		ProjectID:      projectID,
+		Verdict:        review.Verdict,
+		BuiltScopeJSON: string(builtJSON),
+		RawAIOutput:    rawJSON,
+		ProviderUsed:   providerUsed,
+		FallbackUsed:   meta.FallbackUsed,
+		ProviderError:  providerErrPtr,
+		LatencyMS:      meta.LatencyMS,
+		CreatedAt:      time.Now(),
+	}
+	if err := store.SaveReadinessReview(ctx, row); err != nil {
+		return pmodels.ProjectReadinessReview{}, fmt.Errorf("continue: %w", err)
+	}
+	return row, nil
+}
+
+func loadReadinessReviews(ctx context.Context, store persistence.Store, projectID uuid.UUID) ([]pmodels.ProjectReadinessReview, error) {
+	rows, err := store.ListReadinessReviews(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("view: %w", err)
+	}
+	return rows, nil
+}
+
+func builtScopeFromFlag(items []string, stdin io.Reader) ([]string, error) {
+	if len(items) != 1 || strings.TrimSpace(items[0]) != "-" {
+		return cleanFlagList(items), nil
+	}
+	var lines []string
+	sc := bufio.NewScanner(stdin)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*"))
+		lines = append(lines, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("continue: read built scope from stdin: %w", err)
+	}
+	return cleanFlagList(lines), nil
+}
+
+func printReadiness(out io.Writer, review ai.EvolutionReadiness) {
+	tui.Heading(out, "Readiness Review")
+	if review.Ready() {
+		tui.Done(out, "READY for the next evolution")
+	} else {
+		tui.Context(out, "NOT READY for the next evolution")
+	}
+
+	if len(review.BlockingGaps) > 0 {
+		tui.Heading(out, "Blocking Gaps")
+		for _, item := range review.BlockingGaps {
+			fmt.Fprintf(out, "- %s\n", item)
+		}
+	}
+	if len(review.ConcretePrerequisites) > 0 {
+		tui.Heading(out, "Prerequisites")
+		for _, item := range review.ConcretePrerequisites {
+			fmt.Fprintf(out, "- %s\n", item)
+		}
+	}
+	if len(review.RisksIfForced) > 0 {
+		tui.Heading(out, "Risks If Forced")
+		for _, item := range review.RisksIfForced {
+			fmt.Fprintf(out, "- %s\n", item)
+		}
+	}
+}
+
+func printReadinessHistory(out io.Writer, reviews []pmodels.ProjectReadinessReview) {
+	if len(reviews) == 0 {
+		return
+	}
+	tui.Heading(out, "Readiness History")
+	for _, r := range reviews {
+		var built []string
+		_ = json.Unmarshal([]byte(r.BuiltScopeJSON), &built)
+		fmt.Fprintf(out, "- %s  %s  (%d items built)\n", r.CreatedAt.Format("2006-01-02"), r.Verdict, len(built))
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"quibit/internal/persistence"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/tui"
+)
+
+var roadmapCmd = &cobra.Command{
+	Use:   "roadmap <project-id>",
+	Short: "Show a project's accepted evolutions as ordered phases with the scope after each.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, err := uuid.Parse(strings.TrimSpace(args[0]))
+		if err != nil {
+			return withExitCode(exitUsage, fmt.Errorf("roadmap: invalid project id %q", args[0]))
+		}
+
+		ctx := cmd.Context()
+		store, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStore(store)
+
+		row, err := store.GetProject(ctx, projectID)
+		if errors.Is(err, persistence.ErrNotFound) {
+			return fmt.Errorf("roadmap: project %s not found", projectID)
+		}
+		if err != nil {
+			return fmt.Errorf("roadmap: %w", err)
+		}
+		chain, err := loadEvolutionChain(ctx, store, &row)
+		if err != nil {
+			return fmt.Errorf("roadmap: %w", err)
+		}
+
+		doc := newRoadmapDocument(row, chain)
+		if structuredOutput() {
+			return emitDocument(cmd.OutOrStdout(), doc)
+		}
+		printRoadmap(cmd.OutOrStdout(), doc)
+		return nil
+	},
+}
+
+type roadmapDocument struct {
+	Type      string                 `json:"type"`
+	ProjectID string                 `json:"project_id"`
+	Title     string                 `json:"title"`
+	Phases    []roadmapPhaseDocument `json:"phases"`
+}
+
+type roadmapPhaseDocument struct {
+	Phase                int        `json:"phase"`
+	EvolutionID          string     `json:"evolution_id,omitempty"`
+	Contract             string     `json:"contract,omitempty"`
+	CreatedAt            *time.Time `json:"created_at,omitempty"`
+	Goal                 string     `json:"goal"`
+	Added                []string   `json:"added"`
+	Removed              []string   `json:"removed,omitempty"`
+	ArchitecturalChanges []string   `json:"architectural_changes,omitempty"`
+	Scope                []string   `json:"scope"`
+}
+
+func newRoadmapDocument(row pmodels.Project, chain evolutionChain) roadmapDocument {
+	createdAt := row.CreatedAt
+	doc := roadmapDocument{
+		Type:      "roadmap",
+		ProjectID: row.ID.String(),
+		Title:     row.Title,
+		Phases: []roadmapPhaseDocument{{
+			Phase:     0,
+			CreatedAt: &createdAt,
+			Goal:      "MVP",
+			Added:     chain.Base,
+			Scope:     chain.Base,
+		}},
+	}
+	for i, phase := range chain.Phases {
+		evo := chain.Evolutions[i]
+		createdAt := evo.CreatedAt
+		doc.Phases = append(doc.Phases, roadmapPhaseDocument{
+			Phase:                i + 1,
+			EvolutionID:          evo.ID.String(),
+			Contract:             evo.Contract,
+			CreatedAt:            &createdAt,
+			Goal:                 phase.Goal,
+			Added:                phase.Added,
+			Removed:              phase.Removed,
+			ArchitecturalChanges: phase.ArchitecturalChanges,
+			Scope:                phase.Scope,
+		})
+	}
+	return doc
+}
+
+func printRoadmap(out io.Writer, doc roadmapDocument) {
+	tui.Heading(out, "Roadmap · "+doc.Title)
+	if len(doc.Phases) == 1 {
+		tui.Hint(out, "No accepted evolutions yet. Run quibit continue to plan the next phase.")
+	}
+	for _, p := range doc.Phases {
+		title := fmt.Sprintf("Phase %d · %s", p.Phase, p.Goal)
+		if p.CreatedAt != nil {
+			title = fmt.Sprintf("Phase %d · %s · %s", p.Phase, p.CreatedAt.Format("2006-01-02"), p.Goal)
+		}
+		tui.Heading(out, title)
+		if p.Phase > 0 {
+			fmt.Fprintln(out, "Added")
+		}
+		for _, item := range p.Added {
+			fmt.Fprintf(out, "+ %s\n", item)
+		}
+		if len(p.Removed) > 0 {
+			fmt.Fprintln(out, "Removed")
+			for _, item := range p.Removed {
+				fmt.Fprintf(out, "- %s\n", item)
+			}
+		}
+		if len(p.ArchitecturalChanges) > 0 {
+			fmt.Fprintln(out, "Architecture")
+			for _, item := range p.ArchitecturalChanges {
+				fmt.Fprintf(out, "~ %s\n", item)
+			}
+		}
+		tui.Hint(out, fmt.Sprintf("Scope: %d items", len(p.Scope)))
+	}
+
+	if n := len(doc.Phases); n > 1 {
+		tui.Heading(out, "Current Scope")
+		for _, item := range doc.Phases[n-1].Scope {
+			fmt.Fprintf(out, "- %s\n", item)
+		}
+	}
+}
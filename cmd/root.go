@@ -1,19 +1,24 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 	"sync"
+	"syscall"
+	"time"
 
 	"quibit/internal/config"
-	"quibit/internal/db"
-	"quibit/internal/persistence"
 	"quibit/internal/tui"
 
 	"github.com/spf13/cobra"
 )
 
+const interruptGrace = time.Second
+
 var migrate bool
 var noAnim bool
 var noSplash bool
@@ -44,9 +49,12 @@ var rootCmd = &cobra.Command{
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(); err != nil {
+			return err
+		}
 		tui.SetMotionEnabled(!noAnim)
 		if !migrate {
-			if !noSplash && !config.SplashDisabledByEnv() {
+			if !noSplash && !config.SplashDisabledByEnv() && !generateHeadlessRequested(cmd) && !continueHeadlessRequested(cmd) && !structuredOutput() && !isMigrateCmd(cmd) {
 				splashOnce.Do(func() {
 					mode := splashModeFromCmd(cmd)
 					shown, _ := tui.ShowSplashScreen(cmd.Context(), os.Stdin, cmd.OutOrStdout(), mode)
@@ -57,31 +65,9 @@ var rootCmd = &cobra.Command{
 			}
 		}
 		if migrate {
-			{
-				ctx := cmd.Context()
-				out := cmd.OutOrStdout()
-				spin := tui.StartSpinner(ctx, out, "Running database migrations")
-				defer spin.Stop()
-				gdb, err := db.Connect(ctx)
-				if err != nil {
-					return fmt.Errorf("migrate: %w", err)
-				}
-
-				sqlDB, err := gdb.DB()
-				if err != nil {
-					return fmt.Errorf("migrate: get sql db: %w", err)
-				}
-
-				if err := persistence.AutoMigrate(ctx, gdb); err != nil {
-					return fmt.Errorf("migrate: %w", err)
-				}
-				if err := sqlDB.Close(); err != nil {
-					return fmt.Errorf("migrate: close sql db: %w", err)
-				}
-				spin.Stop()
-				tui.Done(out, "Database migrations completed")
+			if err := runMigrateUp(cmd.Context(), cmd.OutOrStdout()); err != nil {
+				return err
 			}
-
 			os.Exit(0)
 			return nil
 		}
@@ -94,9 +80,32 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go exitAfterInterrupt(ctx, stop)
+	err := rootCmd.ExecuteContext(ctx)
+	if err != nil {
+		if ctx.Err() != nil && errors.Is(err, context.Canceled) {
+			fmt.Fprintln(os.Stderr, "interrupted")
+			os.Exit(exitInterrupted)
+		}
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitCodeFor(err))
+	}
+}
+
+// exitAfterInterrupt only steps in for interactive prompts: a read blocked on
+// the terminal never sees the cancelled context, so once one is pending past
+// the grace period the process exits itself. Everything else unwinds through
+// ctx and Execute reports the interrupt.
+func exitAfterInterrupt(ctx context.Context, stop context.CancelFunc) {
+	<-ctx.Done()
+	stop()
+	for {
+		time.Sleep(interruptGrace)
+		if tui.InputPending() {
+			fmt.Fprintln(os.Stderr, "\ninterrupted")
+			os.Exit(exitInterrupted)
+		}
 	}
 }
 
@@ -104,7 +113,15 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&migrate, "migrate", false, "Run database migrations")
 	rootCmd.PersistentFlags().BoolVar(&noAnim, "no-anim", false, "Disable subtle CLI animations")
 	rootCmd.PersistentFlags().BoolVar(&noSplash, "no-splash", false, "Disable startup splash")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", outputText, "Output format (text|json|ndjson)")
 	rootCmd.AddCommand(generateCmd)
 	rootCmd.AddCommand(continueCmd)
 	rootCmd.AddCommand(browseCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(exploreCmd)
+	rootCmd.AddCommand(roadmapCmd)
+	rootCmd.AddCommand(similarityCmd)
+	rootCmd.AddCommand(similarCmd)
+	rootCmd.AddCommand(qualityCmd)
 }
@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"quibit/internal/ai"
+	"quibit/internal/domain"
+	"quibit/internal/persistence"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/project"
+	"quibit/internal/similarity"
+	"quibit/internal/tui"
+)
+
+// similarContentWeight is the share of the content score (title, problem,
+// features, ...) in the relatedness of two saved projects; the rest comes from
+// their DNA.
+const similarContentWeight = 0.6
+
+var (
+	similarCluster   bool
+	similarLimit     int
+	similarThreshold float64
+)
+
+var similarCmd = &cobra.Command{
+	Use:   "similar [project-id]",
+	Short: "Rank saved projects by similarity to one, or cluster the library into themes.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if similarCluster == (len(args) == 1) {
+			return withExitCode(exitUsage, fmt.Errorf("similar: pass a project id or --cluster"))
+		}
+		if similarThreshold < 0 || similarThreshold > 1 {
+			return withExitCode(exitUsage, fmt.Errorf("similar: --threshold must be between 0 and 1"))
+		}
+		var projectID uuid.UUID
+		if len(args) == 1 {
+			id, err := uuid.Parse(strings.TrimSpace(args[0]))
+			if err != nil {
+				return withExitCode(exitUsage, fmt.Errorf("similar: invalid project id %q", args[0]))
+			}
+			projectID = id
+		}
+
+		ctx := cmd.Context()
+		out := cmd.OutOrStdout()
+		store, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStore(store)
+
+		library, err := loadSimilarLibrary(ctx, store, cmd.ErrOrStderr())
+		if err != nil {
+			return fmt.Errorf("similar: %w", err)
+		}
+
+		if similarCluster {
+			doc := newSimilarClustersDocument(library, similarThreshold)
+			if structuredOutput() {
+				return emitDocument(out, doc)
+			}
+			printSimilarClusters(out, doc)
+			return nil
+		}
+
+		target := -1
+		for i := range library {
+			if library[i].Row.ID == projectID {
+				target = i
+				break
+			}
+		}
+		if target < 0 {
+			return fmt.Errorf("similar: project %s not found", projectID)
+		}
+		doc := newSimilarDocument(library, target, similarLimit)
+		if structuredOutput() {
+			return emitDocument(out, doc)
+		}
+		printSimilar(out, doc)
+		return nil
+	},
+}
+
+type similarEntry struct {
+	Row     pmodels.Project
+	Project domain.Project
+	DNA     project.ProjectDNA
+}
+
+type similarPair struct {
+	Content similarity.Breakdown
+	DNA     project.ProjectDNASimilarityBreakdown
+	Score   float64
+}
+
+// loadSimilarLibrary reads every saved project without writing anything back:
+// projects saved before DNA was stored get theirs computed in memory, and rows
+// whose saved output cannot be parsed are skipped with a warning on warn.
+func loadSimilarLibrary(ctx context.Context, store persistence.Store, warn io.Writer) ([]similarEntry, error) {
+	rows, err := store.ListRecentProjects(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	library := make([]similarEntry, 0, len(rows))
+	for i := range rows {
+		var idea ai.ProjectIdea
+		if err := json.Unmarshal([]byte(rows[i].RawAIOutput), &idea); err != nil {
+			tui.Hint(warn, fmt.Sprintf("Skipping %s: cannot parse saved raw_ai_output (%v)", rows[i].ID, err))
+			continue
+		}
+		dna, err := storedProjectDNA(ctx, store, rows[i], idea)
+		if err != nil {
+			return nil, fmt.Errorf("project dna for %s: %w", rows[i].ID, err)
+		}
+		library = append(library, similarEntry{Row: rows[i], Project: ai.DomainProject(idea), DNA: dna})
+	}
+	return library, nil
+}
+
+// storedProjectDNA returns the saved DNA for row, or extracts it from idea when
+// none was stored.
+func storedProjectDNA(ctx context.Context, store persistence.Store, row pmodels.Project, idea ai.ProjectIdea) (project.ProjectDNA, error) {
+	saved, err := store.GetProjectDNA(ctx, row.ID)
+	if errors.Is(err, persistence.ErrNotFound) {
+		return ai.ExtractProjectDNA(idea, row.AppType, row.ProjectKind), nil
+	}
+	if err != nil {
+		return project.ProjectDNA{}, err
+	}
+	return projectDNAFromRow(saved)
+}
+
+func compareSimilarEntries(a, b similarEntry) similarPair {
+	content := similarity.Score(a.Project, b.Project)
+	dna := project.ScoreProjectDNASimilarity(a.DNA, b.DNA)
+	return similarPair{
+		Content: content,
+		DNA:     dna,
+		Score:   similarContentWeight*content.Total + (1-similarContentWeight)*dna.Total,
+	}
+}
+
+type similarDocument struct {
+	Type      string                  `json:"type"`
+	ProjectID string                  `json:"project_id"`
+	Title     string                  `json:"title"`
+	Results   []similarResultDocument `json:"results"`
+}
+
+type similarResultDocument struct {
+	ProjectID string                      `json:"project_id"`
+	Title     string                      `json:"title"`
+	Score     float64                     `json:"score"`
+	Content   similarityBreakdownDocument `json:"content"`
+	DNA       dnaSimilarityDocument       `json:"dna"`
+}
+
+type dnaSimilarityDocument struct {
+	AppType      float64 `json:"app_type"`
+	Domain       float64 `json:"primary_domain"`
+	TechStack    float64 `json:"core_tech_stack"`
+	Architecture float64 `json:"architectural_style"`
+	Complexity   float64 `json:"complexity_level"`
+	Total        float64 `json:"total"`
+}
+
+func newSimilarDocument(library []similarEntry, target, limit int) similarDocument {
+	current := library[target]
+	doc := similarDocument{
+		Type:      "similar",
+		ProjectID: current.Row.ID.String(),
+		Title:     current.Row.Title,
+		Results:   []similarResultDocument{},
+	}
+	for i := range library {
+		if i == target {
+			continue
+		}
+		other := library[i]
+		pair := compareSimilarEntries(current, other)
+		doc.Results = append(doc.Results, similarResultDocument{
+			ProjectID: other.Row.ID.String(),
+			Title:     other.Row.Title,
+			Score:     pair.Score,
+			Content: similarityBreakdownDocument{
+				ComparedProjectID: other.Row.ID.String(),
+				ComparedTitle:     other.Row.Title,
+				Title:             pair.Content.TitleSimilarity,
+				Problem:           pair.Content.ProblemStatementSimilarity,
+				Features:          pair.Content.CoreFeaturesOverlap,
+				Users:             pair.Content.TargetUsersOverlap,
+				Tech:              pair.Content.TechStackOverlap,
+				Complexity:        pair.Content.ComplexityMatch,
+				Total:             pair.Content.Total,
+				Dominant:          similarity.DominantDimension(pair.Content),
+			},
+			DNA: dnaSimilarityDocument{
+				AppType:      pair.DNA.AppTypeSimilarity,
+				Domain:       pair.DNA.PrimaryDomainSimilarity,
+				TechStack:    pair.DNA.CoreTechStackSimilarity,
+				Architecture: pair.DNA.ArchitecturalStyleSimilarity,
+				Complexity:   pair.DNA.ComplexityLevelMatch,
+				Total:        pair.DNA.Total,
+			},
+		})
+	}
+	sort.SliceStable(doc.Results, func(i, j int) bool {
+		return doc.Results[i].Score > doc.Results[j].Score
+	})
+	if limit > 0 && len(doc.Results) > limit {
+		doc.Results = doc.Results[:limit]
+	}
+	return doc
+}
+
+func printSimilar(out io.Writer, doc similarDocument) {
+	tui.Heading(out, "Similar to · "+doc.Title)
+	if len(doc.Results) == 0 {
+		tui.Hint(out, "No other saved projects to compare.")
+		return
+	}
+	for i, r := range doc.Results {
+		fmt.Fprintf(out, "%d. %s  %.2f\n", i+1, r.Title, r.Score)
+		fmt.Fprintf(out, "   ID: %s\n", r.ProjectID)
+		c := r.Content
+		fmt.Fprintf(out, "   Content %.2f  title %.2f · problem %.2f · features %.2f · users %.2f · tech %.2f · complexity %.2f\n",
+			c.Total, c.Title, c.Problem, c.Features, c.Users, c.Tech, c.Complexity)
+		d := r.DNA
+		fmt.Fprintf(out, "   DNA     %.2f  app %.2f · domain %.2f · stack %.2f · architecture %.2f · complexity %.2f\n",
+			d.Total, d.AppType, d.Domain, d.TechStack, d.Architecture, d.Complexity)
+		if c.Dominant != "" {
+			fmt.Fprintf(out, "   Dominant: %s\n", c.Dominant)
+		}
+	}
+}
+
+type similarClustersDocument struct {
+	Type      string                   `json:"type"`
+	Threshold float64                  `json:"threshold"`
+	Projects  int                      `json:"projects"`
+	Clusters  []similarClusterDocument `json:"clusters"`
+}
+
+type similarClusterDocument struct {
+	Theme    string                  `json:"theme"`
+	Cohesion float64                 `json:"cohesion"`
+	Projects []similarMemberDocument `json:"projects"`
+}
+
+type similarMemberDocument struct {
+	ProjectID string `json:"project_id"`
+	Title     string `json:"title"`
+	Domain    string `json:"primary_domain,omitempty"`
+}
+
+func newSimilarClustersDocument(library []similarEntry, threshold float64) similarClustersDocument {
+	n := len(library)
+	scores := make([][]float64, n)
+	for i := range scores {
+		scores[i] = make([]float64, n)
+		for j := 0; j < i; j++ {
+			scores[i][j] = compareSimilarEntries(library[i], library[j]).Score
+			scores[j][i] = scores[i][j]
+		}
+	}
+
+	doc := similarClustersDocument{
+		Type:      "similar_clusters",
+		Threshold: threshold,
+		Projects:  n,
+		Clusters:  []similarClusterDocument{},
+	}
+	groups := similarity.Cluster(scores, threshold)
+	for _, members := range groups {
+		cluster := similarClusterDocument{Projects: make([]similarMemberDocument, 0, len(members))}
+		domains := make([]string, 0, len(members))
+		sum, pairs := 0.0, 0
+		for a, i := range members {
+			e := library[i]
+			cluster.Projects = append(cluster.Projects, similarMemberDocument{
+				ProjectID: e.Row.ID.String(),
+				Title:     e.Row.Title,
+				Domain:    e.DNA.PrimaryDomain,
+			})
+			domains = append(domains, e.DNA.PrimaryDomain)
+			for _, j := range members[a+1:] {
+				sum += scores[i][j]
+				pairs++
+			}
+		}
+		if pairs > 0 {
+			cluster.Cohesion = sum / float64(pairs)
+		} else {
+			cluster.Cohesion = 1
+		}
+		cluster.Theme = clusterTheme(domains)
+		doc.Clusters = append(doc.Clusters, cluster)
+	}
+	return doc
+}
+
+// clusterTheme names a cluster after the primary domain most of its projects
+// share.
+func clusterTheme(domains []string) string {
+	counts := map[string]int{}
+	best, bestCount := "", 0
+	for _, d := range domains {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		counts[strings.ToLower(d)]++
+		if c := counts[strings.ToLower(d)]; c > bestCount {
+			best, bestCount = d, c
+		}
+	}
+	if best == "" {
+		return "Uncategorized"
+	}
+	return best
+}
+
+func printSimilarClusters(out io.Writer, doc similarClustersDocument) {
+	tui.Heading(out, "Project Themes")
+	if doc.Projects == 0 {
+		tui.Hint(out, "No saved projects yet.")
+		return
+	}
+	tui.Hint(out, fmt.Sprintf("%d projects · %d themes · threshold %.2f", doc.Projects, len(doc.Clusters), doc.Threshold))
+	for i, c := range doc.Clusters {
+		fmt.Fprintln(out, "")
+		if len(c.Projects) == 1 {
+			fmt.Fprintf(out, "%d. %s  (1 project)\n", i+1, c.Theme)
+		} else {
+			fmt.Fprintf(out, "%d. %s  (%d projects, cohesion %.2f)\n", i+1, c.Theme, len(c.Projects), c.Cohesion)
+		}
+		for _, p := range c.Projects {
+			fmt.Fprintf(out, "   - %s  %s\n", p.Title, p.ProjectID)
+		}
+	}
+}
+
+func init() {
+	similarCmd.Flags().BoolVar(&similarCluster, "cluster", false, "Group every saved project into themes instead of ranking against one")
+	similarCmd.Flags().IntVar(&similarLimit, "limit", 10, "Maximum number of ranked projects to show (0 = all)")
+	similarCmd.Flags().Float64Var(&similarThreshold, "threshold", 0.45, "Minimum average similarity for projects to share a theme")
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quibit/internal/persistence"
+	pmodels "quibit/internal/persistence/models"
+)
+
+// readOnlyStore fails the test on any DNA write and reports every project as
+// saved before DNA was stored.
+type readOnlyStore struct {
+	persistence.Store
+	t *testing.T
+}
+
+func (s readOnlyStore) GetProjectDNA(context.Context, uuid.UUID) (pmodels.ProjectDNA, error) {
+	return pmodels.ProjectDNA{}, persistence.ErrNotFound
+}
+
+func (s readOnlyStore) SaveProjectDNA(context.Context, pmodels.ProjectDNA) error {
+	s.t.Error("similar wrote project DNA")
+	return nil
+}
+
+func TestSimilarClusterIsReadOnlyAndSkipsBadRows(t *testing.T) {
+	store := useMemoryStore(t)
+	raw, err := os.ReadFile("../internal/ai/testdata/project_idea.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, output := range []string{string(raw), "not json"} {
+		row := pmodels.Project{
+			ID:          uuid.New(),
+			Title:       "Project",
+			DNAHash:     uuid.NewString(),
+			RawAIOutput: output,
+			AppType:     "cli",
+			CreatedAt:   time.Now().Add(time.Duration(i) * time.Second),
+		}
+		if err := store.SaveProject(context.Background(), persistence.ProjectRecord{Project: row}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	openStore = func(context.Context) (persistence.Store, error) { return readOnlyStore{Store: store, t: t}, nil }
+
+	var doc similarClustersDocument
+	out := runCommand(t, "similar", "--cluster", "--output", "json")
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("decode similar output: %v\n%s", err, out)
+	}
+	if doc.Projects != 1 || len(doc.Clusters) != 1 {
+		t.Fatalf("clustered %d projects into %d themes, want the one parsable project", doc.Projects, len(doc.Clusters))
+	}
+	if doc.Clusters[0].Projects[0].Domain == "" {
+		t.Error("in-memory DNA has no primary domain")
+	}
+}
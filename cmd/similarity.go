@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quibit/internal/ai"
+	"quibit/internal/domain"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/project"
+	"quibit/internal/similarity"
+	"quibit/internal/tui"
+)
+
+// decideSimilarity applies the thresholds from SIMILARITY_ACCEPTABLE_MAX and
+// SIMILARITY_TOO_SIMILAR_MAX to a blended score.
+func decideSimilarity(score float64) project.SimilarityDecision {
+	switch similarity.Categorize(score, similarity.LoadThresholdsFromEnv()) {
+	case similarity.CategoryDuplicate:
+		return project.SimilarityBlock
+	case similarity.CategoryTooSimilar:
+		return project.SimilarityRegenerate
+	default:
+		return project.SimilarityOK
+	}
+}
+
+// similarNeighborCount is how many saved projects beyond the closest one a
+// regeneration is told to avoid.
+const similarNeighborCount = 3
+
+// similarityMatch is the saved project closest to a generated idea, with the
+// per-dimension breakdown that explains the score. Score is the blended score
+// the decision was made on; Breakdown.Total only weighs the dimensions.
+// Vector is the idea's own embedding, saved with it when accepted. Neighbors
+// are the next closest saved projects, best first.
+type similarityMatch struct {
+	ProjectID   uuid.UUID
+	Reference   domain.Project
+	Breakdown   similarity.Breakdown
+	Dominant    string
+	Semantic    float64
+	Score       float64
+	Vector      []float32
+	VectorModel string
+	Neighbors   []similarNeighbor
+}
+
+type similarNeighbor struct {
+	ProjectID uuid.UUID
+	Project   domain.Project
+}
+
+func newSimilarityMatch(current domain.Project, row pmodels.Project, ref domain.Project) *similarityMatch {
+	b := similarity.Score(current, ref)
+	return &similarityMatch{
+		ProjectID: row.ID,
+		Reference: ref,
+		Breakdown: b,
+		Dominant:  similarity.DominantDimension(b),
+	}
+}
+
+// pivot aims the regeneration at the dimension that made the idea similar,
+// naming the reference project to move away from.
+func (m *similarityMatch) pivot() ai.Pivot {
+	return ai.BuildPivot(m.Dominant, m.Reference)
+}
+
+func (m *similarityMatch) pivotStrategy() ai.PivotStrategy {
+	switch m.pivot().Reason {
+	case "target users":
+		return ai.PivotChangeTargetUser
+	case "features":
+		return ai.PivotFeatureReplacement
+	default:
+		return ai.PivotContextShift
+	}
+}
+
+// regenerationHistory remembers what was rejected during one generate run so
+// every later regeneration is told to avoid all of it, not only the last
+// attempt.
+type regenerationHistory struct {
+	rejected []ai.ProjectIdea
+	similar  []similarNeighbor
+}
+
+// reject records idea and the saved projects that were closest to it.
+func (h *regenerationHistory) reject(idea ai.ProjectIdea, m *similarityMatch) {
+	h.rejected = append(h.rejected, idea)
+	if m == nil {
+		return
+	}
+	h.addSimilar(similarNeighbor{ProjectID: m.ProjectID, Project: m.Reference})
+	for _, n := range m.Neighbors {
+		h.addSimilar(n)
+	}
+}
+
+func (h *regenerationHistory) addSimilar(n similarNeighbor) {
+	for i := range h.similar {
+		if h.similar[i].ProjectID == n.ProjectID {
+			return
+		}
+	}
+	h.similar = append(h.similar, n)
+}
+
+// avoid returns the pivot reference for named, if any, and the avoid-list of
+// everything rejected so far. The saved project named by the reference is not
+// repeated; at most similarNeighborCount of the most recently seen others are
+// kept.
+func (h *regenerationHistory) avoid(named *similarityMatch) (ai.AvoidList, []ai.Pivot) {
+	var refs []ai.Pivot
+	if named != nil {
+		refs = []ai.Pivot{named.pivot()}
+	}
+	avoid := ai.AvoidList{Rejected: h.rejected}
+	for i := len(h.similar) - 1; i >= 0 && len(avoid.Similar) < similarNeighborCount; i-- {
+		n := h.similar[i]
+		if named != nil && n.ProjectID == named.ProjectID {
+			continue
+		}
+		avoid.Similar = append(avoid.Similar, n.Project)
+	}
+	return avoid, refs
+}
+
+func newProjectSimilarityRow(projectID uuid.UUID, m *similarityMatch) pmodels.ProjectSimilarity {
+	return pmodels.ProjectSimilarity{
+		ID:                uuid.New(),
+		ProjectID:         projectID,
+		ComparedProjectID: m.ProjectID,
+		TitleScore:        m.Breakdown.TitleSimilarity,
+		ProblemScore:      m.Breakdown.ProblemStatementSimilarity,
+		FeaturesScore:     m.Breakdown.CoreFeaturesOverlap,
+		UsersScore:        m.Breakdown.TargetUsersOverlap,
+		TechScore:         m.Breakdown.TechStackOverlap,
+		ComplexityScore:   m.Breakdown.ComplexityMatch,
+		SemanticScore:     m.Semantic,
+		SimilarityScore:   m.Score,
+		DominantDimension: m.Dominant,
+		CreatedAt:         time.Now(),
+	}
+}
+
+type similarityBreakdownDocument struct {
+	ComparedProjectID string  `json:"compared_project_id"`
+	ComparedTitle     string  `json:"compared_title,omitempty"`
+	Title             float64 `json:"title"`
+	Problem           float64 `json:"problem"`
+	Features          float64 `json:"features"`
+	Users             float64 `json:"users"`
+	Tech              float64 `json:"tech"`
+	Complexity        float64 `json:"complexity"`
+	Semantic          float64 `json:"semantic,omitempty"`
+	// Total is the weighted sum of the dimensions above; Score is the blended
+	// score the accept/regenerate/block decision was made on.
+	Total    float64 `json:"total"`
+	Score    float64 `json:"score,omitempty"`
+	Dominant string  `json:"dominant_dimension"`
+}
+
+func newSimilarityBreakdownDocument(m *similarityMatch) *similarityBreakdownDocument {
+	if m == nil {
+		return nil
+	}
+	return &similarityBreakdownDocument{
+		ComparedProjectID: m.ProjectID.String(),
+		ComparedTitle:     m.Reference.Title,
+		Title:             m.Breakdown.TitleSimilarity,
+		Problem:           m.Breakdown.ProblemStatementSimilarity,
+		Features:          m.Breakdown.CoreFeaturesOverlap,
+		Users:             m.Breakdown.TargetUsersOverlap,
+		Tech:              m.Breakdown.TechStackOverlap,
+		Complexity:        m.Breakdown.ComplexityMatch,
+		Semantic:          m.Semantic,
+		Total:             m.Breakdown.Total,
+		Score:             m.Score,
+		Dominant:          m.Dominant,
+	}
+}
+
+func savedSimilarityBreakdownDocument(row pmodels.ProjectSimilarity) *similarityBreakdownDocument {
+	doc := &similarityBreakdownDocument{
+		ComparedProjectID: row.ComparedProjectID.String(),
+		Title:             row.TitleScore,
+		Problem:           row.ProblemScore,
+		Features:          row.FeaturesScore,
+		Users:             row.UsersScore,
+		Tech:              row.TechScore,
+		Complexity:        row.ComplexityScore,
+		Semantic:          row.SemanticScore,
+		Score:             row.SimilarityScore,
+		Dominant:          row.DominantDimension,
+	}
+	doc.Total = similarity.WeightedTotal(similarity.Breakdown{
+		TitleSimilarity:            doc.Title,
+		ProblemStatementSimilarity: doc.Problem,
+		CoreFeaturesOverlap:        doc.Features,
+		TargetUsersOverlap:         doc.Users,
+		TechStackOverlap:           doc.Tech,
+		ComplexityMatch:            doc.Complexity,
+	})
+	return doc
+}
+
+func printSimilarityBreakdown(out io.Writer, doc *similarityBreakdownDocument) {
+	if doc == nil {
+		return
+	}
+	tui.Heading(out, "Similarity Breakdown")
+	ref := doc.ComparedProjectID
+	if doc.ComparedTitle != "" {
+		ref = doc.ComparedTitle + " (" + doc.ComparedProjectID + ")"
+	}
+	fmt.Fprintf(out, "Closest saved project: %s\n", ref)
+	rows := []struct {
+		key   string
+		label string
+		value float64
+	}{
+		{"title", "Title", doc.Title},
+		{"problem", "Problem", doc.Problem},
+		{"features", "Features", doc.Features},
+		{"target users", "Users", doc.Users},
+		{"tech stack", "Tech", doc.Tech},
+		{"", "Complexity", doc.Complexity},
+	}
+	for _, r := range rows {
+		marker := ""
+		if r.key != "" && r.key == doc.Dominant {
+			marker = "  ← dominant"
+		}
+		fmt.Fprintf(out, "  %-11s %.2f%s\n", r.label, r.value, marker)
+	}
+	fmt.Fprintf(out, "  %-11s %.2f  weighted sum of the dimensions\n", "Weighted", doc.Total)
+	fmt.Fprintf(out, "  %-11s %.2f\n", "Semantic", doc.Semantic)
+	if doc.Score > 0 {
+		fmt.Fprintf(out, "  %-11s %.2f  token/semantic blend the decision used\n", "Score", doc.Score)
+	}
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"quibit/internal/ai"
+	"quibit/internal/model"
+	"quibit/internal/persistence"
+	pmodels "quibit/internal/persistence/models"
+	"quibit/internal/project"
+	"quibit/internal/similarity"
+	"quibit/internal/tui"
+)
+
+var similarityCmd = &cobra.Command{
+	Use:   "similarity",
+	Short: "Maintain the similarity index of saved projects.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var similarityRebuildIndexCmd = &cobra.Command{
+	Use:   "rebuild-index",
+	Short: "Rebuild the MinHash LSH index from every saved project.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		store, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeStore(store)
+		return runRebuildSimilarityIndex(ctx, store, cmd.OutOrStdout())
+	},
+}
+
+type similarityIndexDocument struct {
+	Type     string `json:"type"`
+	Projects int    `json:"projects"`
+	Buckets  int    `json:"buckets"`
+}
+
+func runRebuildSimilarityIndex(ctx context.Context, store persistence.Store, out io.Writer) error {
+	spin := tui.StartSpinner(ctx, out, "Rebuilding similarity index")
+	rows, err := store.ListRecentProjects(ctx, 0)
+	if err != nil {
+		spin.Stop()
+		return fmt.Errorf("similarity: %w", err)
+	}
+	index := make(map[uuid.UUID][]string, len(rows))
+	total := 0
+	for i := range rows {
+		buckets, err := projectLSHBuckets(rows[i])
+		if err != nil {
+			spin.Stop()
+			return fmt.Errorf("similarity: project %s: %w", rows[i].ID, err)
+		}
+		index[rows[i].ID] = buckets
+		total += len(buckets)
+	}
+	err = store.RebuildLSHIndex(ctx, index)
+	spin.Stop()
+	if err != nil {
+		return fmt.Errorf("similarity: %w", err)
+	}
+
+	doc := similarityIndexDocument{Type: "similarity_index", Projects: len(rows), Buckets: total}
+	if structuredOutput() {
+		return emitDocument(out, doc)
+	}
+	tui.Done(out, fmt.Sprintf("Indexed %d projects (%d buckets)", doc.Projects, doc.Buckets))
+	return nil
+}
+
+// similarityCandidates is every saved project worth a full comparison: the
+// most recent SIMILARITY_LOOKBACK_N projects plus older ones that share an LSH
+// bucket with the given buckets.
+func similarityCandidates(ctx context.Context, store persistence.Store, buckets []string) ([]pmodels.Project, error) {
+	rows, err := store.ListRecentProjects(ctx, loadSimilarityLimit())
+	if err != nil {
+		return nil, err
+	}
+	ids, err := store.FindLSHCandidates(ctx, buckets)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[uuid.UUID]struct{}, len(rows))
+	for i := range rows {
+		seen[rows[i].ID] = struct{}{}
+	}
+	var older []uuid.UUID
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			older = append(older, id)
+		}
+	}
+	if len(older) == 0 {
+		return rows, nil
+	}
+	more, err := store.ListProjectsByID(ctx, older)
+	if err != nil {
+		return nil, err
+	}
+	return append(rows, more...), nil
+}
+
+func projectLSHBuckets(row pmodels.Project) ([]string, error) {
+	snap, err := rowSnapshot(row)
+	if err != nil {
+		return nil, err
+	}
+	buckets := lshBuckets("idea", snap)
+	return append(buckets, lshBuckets("input", rowInputSnapshot(row, snap.TechStack))...), nil
+}
+
+func lshBuckets(kind string, snap project.Snapshot) []string {
+	tokens := project.SnapshotTokens(snap)
+	if len(tokens) == 0 {
+		return nil
+	}
+	return similarity.LSHBuckets(kind, similarity.MinHash(tokens))
+}
+
+func rowSnapshot(row pmodels.Project) (project.Snapshot, error) {
+	mvp, err := parseStringArray(row.MVPScopeJSON)
+	if err != nil {
+		return project.Snapshot{}, fmt.Errorf("parse mvp scope: %w", err)
+	}
+	stack, err := parseStringArray(row.TechStackJSON)
+	if err != nil {
+		return project.Snapshot{}, fmt.Errorf("parse tech stack: %w", err)
+	}
+	return project.Snapshot{
+		Overview:          row.ProjectOverview,
+		MVPScope:          mvp,
+		TechStack:         stack,
+		Complexity:        row.Complexity,
+		EstimatedDuration: row.Duration,
+		AppType:           row.AppType,
+		Goal:              row.Goal,
+	}, nil
+}
+
+// rowInputSnapshot keeps only the fields known before generation, so the
+// precheck can compare raw input against saved projects.
+func rowInputSnapshot(row pmodels.Project, stack []string) project.Snapshot {
+	return project.Snapshot{
+		MVPScope:          []string{},
+		TechStack:         stack,
+		Complexity:        row.Complexity,
+		EstimatedDuration: row.Duration,
+		AppType:           row.AppType,
+		Goal:              row.Goal,
+	}
+}
+
+func inputSnapshot(input model.ProjectInput) project.Snapshot {
+	return project.Snapshot{
+		MVPScope:          []string{},
+		TechStack:         input.TechStack,
+		Complexity:        input.Complexity,
+		EstimatedDuration: input.Timeframe,
+		AppType:           input.AppType,
+		Goal:              input.Goal,
+	}
+}
+
+func ideaSnapshot(idea ai.ProjectIdea, input model.ProjectInput) project.Snapshot {
+	return project.Snapshot{
+		Overview:          buildProjectOverview(idea),
+		MVPScope:          idea.Project.MVP.MustHave,
+		TechStack:         flattenTechStack(idea.Project.TechStack),
+		Complexity:        idea.Project.Complexity,
+		EstimatedDuration: idea.Project.Duration.Range,
+		AppType:           input.AppType,
+		Goal:              input.Goal,
+	}
+}
+
+func init() {
+	similarityCmd.AddCommand(similarityRebuildIndexCmd)
+}
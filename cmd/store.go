@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"context"
+
+	"quibit/internal/persistence"
+)
+
+var openStore = func(ctx context.Context) (persistence.Store, error) {
+	return persistence.Open(ctx)
+}
+
+// closeStore records the run's JSON repair counters through store before
+// closing it, so they are written on the connection the command already has.
+func closeStore(store persistence.Store) {
+	if store != nil {
+		flushJSONRepairCounts(store)
+		_ = store.Close()
+	}
+}
@@ -60,6 +60,9 @@ const constrainedExpansionPromptTemplate = "" +
 	"  - <string>\n" +
 	"  - <string>\n" +
 	"  - <string>\n" +
+	"- Nice To Have Features (2-3 items; concrete, not placeholders):\n" +
+	"  - <string>\n" +
+	"  - <string>\n" +
 	"- Out Of Scope (exactly 4 items):\n" +
 	"  - <string>\n" +
 	"  - <string>\n" +
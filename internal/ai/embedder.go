@@ -0,0 +1,178 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/genai"
+
+	"quibit/internal/config"
+	"quibit/internal/similarity"
+)
+
+const (
+	defaultGeminiEmbeddingModel = "text-embedding-004"
+	defaultOpenAIEmbeddingModel = "text-embedding-3-small"
+)
+
+// NewEmbedder returns the embedder selected by QUIBIT_EMBEDDER: local (the
+// default, works offline), gemini or openai.
+func NewEmbedder(cfg config.AIConfig) (similarity.Embedder, error) {
+	switch cfg.Embedder {
+	case "", "local":
+		return similarity.NewLocalEmbedder(), nil
+	case "gemini":
+		if strings.TrimSpace(cfg.GeminiAPIKey) == "" {
+			return nil, fmt.Errorf("embedder: GEMINI_API_KEY is required for the gemini embedder")
+		}
+		model := cfg.EmbeddingModel
+		if model == "" {
+			model = defaultGeminiEmbeddingModel
+		}
+		return &geminiEmbedder{apiKey: cfg.GeminiAPIKey, model: model}, nil
+	case "openai":
+		oc, err := config.LoadOpenAIConfig(cfg.OpenAIConfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("embedder: %w", err)
+		}
+		baseURL := strings.TrimRight(strings.TrimSpace(oc.BaseURL), "/")
+		if baseURL == "" {
+			return nil, fmt.Errorf("embedder: OPENAI_BASE_URL is required for the openai embedder")
+		}
+		model := cfg.EmbeddingModel
+		if model == "" {
+			model = defaultOpenAIEmbeddingModel
+		}
+		timeout := openAIDefaultTimeout
+		if oc.TimeoutSeconds > 0 {
+			timeout = time.Duration(oc.TimeoutSeconds) * time.Second
+		}
+		return &openAIEmbedder{
+			baseURL: baseURL,
+			model:   model,
+			apiKey:  strings.TrimSpace(oc.APIKey),
+			headers: oc.Headers,
+			client:  &http.Client{Timeout: timeout},
+		}, nil
+	default:
+		return nil, fmt.Errorf("embedder: unknown embedder %q (local, gemini, openai)", cfg.Embedder)
+	}
+}
+
+type geminiEmbedder struct {
+	apiKey string
+	model  string
+}
+
+func (e *geminiEmbedder) Model() string { return "gemini/" + e.model }
+
+func (e *geminiEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+	client, err := NewGeminiClient(ctx, e.apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("gemini embed: %w", err)
+	}
+	contents := make([]*genai.Content, 0, len(texts))
+	for _, text := range texts {
+		contents = append(contents, genai.NewContentFromText(text, genai.RoleUser))
+	}
+	resp, err := client.Models.EmbedContent(ctx, e.model, contents, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gemini embed (model %s): %w", e.model, err)
+	}
+	if resp == nil || len(resp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("gemini embed (model %s): expected %d embeddings", e.model, len(texts))
+	}
+	out := make([][]float32, 0, len(texts))
+	for _, emb := range resp.Embeddings {
+		if emb == nil || len(emb.Values) == 0 {
+			return nil, fmt.Errorf("gemini embed (model %s): empty embedding", e.model)
+		}
+		out = append(out, emb.Values)
+	}
+	return out, nil
+}
+
+type openAIEmbedder struct {
+	baseURL string
+	model   string
+	apiKey  string
+	headers map[string]string
+	client  *http.Client
+}
+
+func (e *openAIEmbedder) Model() string { return "openai/" + e.model }
+
+func (e *openAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+	b, err := json.Marshal(struct {
+		Model string   `json:"model"`
+		Input []string `json:"input"`
+	}{Model: e.model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("openai embed: marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/embeddings", bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("openai embed: build request: %w", err)
+	}
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openai embed: request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	rawBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := strings.TrimSpace(string(rawBody))
+		if msg == "" {
+			msg = resp.Status
+		}
+		return nil, fmt.Errorf("openai embed: http %d: %s", resp.StatusCode, msg)
+	}
+
+	var out struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rawBody, &out); err != nil {
+		return nil, fmt.Errorf("openai embed: decode response: %w", err)
+	}
+	if len(out.Data) != len(texts) {
+		return nil, fmt.Errorf("openai embed: expected %d embeddings, got %d", len(texts), len(out.Data))
+	}
+	vectors := make([][]float32, len(texts))
+	for _, d := range out.Data {
+		if d.Index < 0 || d.Index >= len(texts) || len(d.Embedding) == 0 {
+			return nil, fmt.Errorf("openai embed: invalid embedding at index %d", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	for i := range vectors {
+		if vectors[i] == nil {
+			return nil, fmt.Errorf("openai embed: missing embedding at index %d", i)
+		}
+	}
+	return vectors, nil
+}
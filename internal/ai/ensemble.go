@@ -0,0 +1,184 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"quibit/internal/config"
+	"quibit/internal/model"
+)
+
+// EnsembleCandidate is one provider's answer in an ensemble round. Err is set
+// when the provider failed or its answer did not decode or validate.
+type EnsembleCandidate struct {
+	Provider string
+	Idea     ProjectIdea
+	RawJSON  string
+	Meta     AIResult
+	Err      error
+
+	verdict qualityVerdict
+}
+
+// Accepted reports whether the candidate decoded and passed the quality gate.
+func (c EnsembleCandidate) Accepted() bool {
+	return c.Err == nil && c.verdict.ok()
+}
+
+// Verdict is the quality gate decision, or FAILED when there was no idea to
+// judge.
+func (c EnsembleCandidate) Verdict() string {
+	if c.Err != nil {
+		return "FAILED"
+	}
+	return string(c.verdict.decision)
+}
+
+// Summary explains the verdict in one line.
+func (c EnsembleCandidate) Summary() string {
+	if c.Err != nil {
+		return sanitizeErr(c.Err)
+	}
+	return c.verdict.summary()
+}
+
+// RetryStrategy is the pivot a regeneration should use after this candidate
+// failed the quality gate, as the single-provider retry loop would pick it.
+func (c EnsembleCandidate) RetryStrategy(attempt int) PivotStrategy {
+	if c.Err != nil {
+		return rotatePivotStrategy(attempt)
+	}
+	return retryStrategy(&c.verdict, attempt)
+}
+
+// GenerateProjectIdeaEnsemble sends prompt to every configured provider at
+// once instead of falling back through them. Each answer is decoded, validated
+// and put through the quality gate; candidates come back in provider order.
+// It fails only when every provider errored or ctx was cancelled.
+func GenerateProjectIdeaEnsemble(ctx context.Context, in model.ProjectInput, prompt string) ([]EnsembleCandidate, error) {
+	cfg := config.LoadAIConfig()
+	m, err := NewProviderChain(cfg)
+	if err != nil {
+		return nil, err
+	}
+	gate, err := newQualityGate(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cands := make([]EnsembleCandidate, len(m.providers))
+	var wg sync.WaitGroup
+	for i, p := range m.providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cands[i] = runEnsembleProvider(ctx, m, p, gate, in, prompt)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	for i, c := range cands {
+		if c.Meta.ProviderUsed == "" {
+			d := DiagnoseProviderError(c.Provider, c.Err)
+			fmt.Fprintf(&b, "\n\nProvider %d/%d (%s)\n- Error: %s\n- Diagnosis: %s\n- What you can do: %s",
+				i+1, len(cands), c.Provider, sanitizeErr(c.Err), d.Summary, d.Action)
+			continue
+		}
+		return cands, nil
+	}
+	return nil, fmt.Errorf("%w%s", ErrProvidersFailed, b.String())
+}
+
+func runEnsembleProvider(ctx context.Context, m *ProviderManager, p AIProvider, gate *qualityGate, in model.ProjectInput, prompt string) EnsembleCandidate {
+	c := EnsembleCandidate{Provider: p.Name()}
+	release, err := acquireProviderSlot(ctx, p.Name(), m.limits[p.Name()])
+	if err != nil {
+		c.Err = err
+		return c
+	}
+	start := time.Now()
+	res, err := p.Generate(ctx, PromptPayload{Prompt: prompt, JSON: true, Schema: ProjectIdeaSchema})
+	release()
+	if err != nil {
+		c.Err = err
+		return c
+	}
+	if res.ProviderUsed == "" {
+		res.ProviderUsed = p.Name()
+	}
+	res.LatencyMS = time.Since(start).Milliseconds()
+	c.Meta = res
+
+	c.RawJSON = m.normalizeJSONAnswer(ctx, &c.Meta, ProjectIdeaSchema)
+	if c.Idea, c.Err = decodeProjectIdea(c.RawJSON, in); c.Err != nil {
+		return c
+	}
+	if c.verdict, c.Err = gate.evaluate(ctx, c.Idea); c.Err != nil {
+		return c
+	}
+	c.Meta.Quality = &c.verdict.scores
+	return c
+}
+
+// RankEnsemble orders candidates best first: decoded before failed, then by
+// quality verdict (ACCEPT, REFINE, PIVOT, REGENERATE), then by distance from
+// the saved library (similarity[i] belongs to cands[i]), then by latency.
+func RankEnsemble(cands []EnsembleCandidate, similarity []float64) []int {
+	order := make([]int, len(cands))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ca, cb := cands[order[a]], cands[order[b]]
+		if ra, rb := ensembleVerdictRank(ca), ensembleVerdictRank(cb); ra != rb {
+			return ra < rb
+		}
+		if sa, sb := similarity[order[a]], similarity[order[b]]; sa != sb {
+			return sa < sb
+		}
+		return ca.Meta.LatencyMS < cb.Meta.LatencyMS
+	})
+	return order
+}
+
+func ensembleVerdictRank(c EnsembleCandidate) int {
+	if c.Err != nil {
+		return 5
+	}
+	if c.verdict.hardFail {
+		return 4
+	}
+	switch c.verdict.decision {
+	case qualityAccept:
+		return 0
+	case qualityRefine:
+		return 1
+	case qualityPivot:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// EnsembleWinnerMeta is the winner's AIResult with ProviderUsed naming it and
+// ProviderError listing the providers that failed outright.
+func EnsembleWinnerMeta(cands []EnsembleCandidate, winner int) AIResult {
+	meta := cands[winner].Meta
+	meta.ProviderUsed = cands[winner].Provider
+	var errs []string
+	for i, c := range cands {
+		if i != winner && c.Meta.ProviderUsed == "" && c.Err != nil {
+			errs = append(errs, c.Provider+": "+sanitizeErr(c.Err))
+		}
+	}
+	meta.ProviderError = strings.Join(errs, "\n")
+	return meta
+}
@@ -0,0 +1,95 @@
+package ai
+
+import "strings"
+
+// EvolutionPhase is one accepted evolution applied to everything before it.
+type EvolutionPhase struct {
+	Goal                 string
+	Added                []string
+	Removed              []string
+	ArchitecturalChanges []string
+	Scope                []string
+}
+
+// BuildEvolutionChain applies accepted evolutions, oldest first, to the
+// original MVP scope. A next-phase evolution replaces the scope with its
+// stored scope version (or, without one, its updated MVP scope) minus what it
+// put out of scope. Legacy project evolutions only add their proposed
+// enhancements.
+func BuildEvolutionChain(baseScope []string, evolutions []SavedEvolution) []EvolutionPhase {
+	scope := appendUnique(nil, baseScope...)
+	phases := make([]EvolutionPhase, 0, len(evolutions))
+	for _, evo := range evolutions {
+		var phase EvolutionPhase
+		next := scope
+		switch {
+		case evo.NextPhase != nil:
+			phase.Goal = strings.TrimSpace(evo.NextPhase.EvolutionGoal)
+			phase.ArchitecturalChanges = appendUnique(nil, evo.NextPhase.ArchitecturalChanges...)
+			version := ScopeVersion{MustHave: evo.NextPhase.UpdatedMVPScope.MustHave, OutOfScope: evo.NextPhase.UpdatedMVPScope.OutOfScope}
+			if evo.Scope != nil {
+				version = *evo.Scope
+			}
+			next = removeItems(appendUnique(nil, version.MustHave...), version.OutOfScope)
+		case evo.Project != nil:
+			phase.Goal = strings.TrimSpace(evo.Project.EvolutionOverview)
+			next = appendUnique(append([]string{}, scope...), evo.Project.ProposedEnhancements...)
+		}
+
+		phase.Added = removeItems(append([]string{}, next...), scope)
+		phase.Removed = removeItems(append([]string{}, scope...), next)
+		phase.Scope = append([]string{}, next...)
+		scope = next
+		phases = append(phases, phase)
+	}
+	return phases
+}
+
+// EvolvedStack is the original tech stack followed by the architectural
+// changes of every accepted phase, oldest first.
+func EvolvedStack(baseStack []string, phases []EvolutionPhase) []string {
+	stack := appendUnique(nil, baseStack...)
+	for _, p := range phases {
+		stack = appendUnique(stack, p.ArchitecturalChanges...)
+	}
+	return stack
+}
+
+// removeItems drops from items everything in drop, compared case-insensitively.
+func removeItems(items []string, drop []string) []string {
+	skip := make(map[string]struct{}, len(drop))
+	for _, v := range drop {
+		skip[strings.ToLower(strings.TrimSpace(v))] = struct{}{}
+	}
+	out := items[:0]
+	for _, v := range items {
+		if _, ok := skip[strings.ToLower(strings.TrimSpace(v))]; ok {
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func appendUnique(dst []string, items ...string) []string {
+	seen := make(map[string]struct{}, len(dst)+len(items))
+	for _, v := range dst {
+		seen[strings.ToLower(strings.TrimSpace(v))] = struct{}{}
+	}
+	for _, v := range items {
+		v = strings.TrimSpace(v)
+		key := strings.ToLower(v)
+		if v == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		dst = append(dst, v)
+	}
+	if dst == nil {
+		dst = []string{}
+	}
+	return dst
+}
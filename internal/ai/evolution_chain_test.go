@@ -0,0 +1,18 @@
+package ai
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvolvedStack(t *testing.T) {
+	phases := []EvolutionPhase{
+		{ArchitecturalChanges: []string{"Add Redis job queue", "go"}},
+		{ArchitecturalChanges: []string{"Split ingestion into a worker service"}},
+	}
+	got := EvolvedStack([]string{"Go", "PostgreSQL"}, phases)
+	want := []string{"Go", "PostgreSQL", "Add Redis job queue", "Split ingestion into a worker service"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EvolvedStack = %v, want %v", got, want)
+	}
+}
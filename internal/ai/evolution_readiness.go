@@ -0,0 +1,84 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	ReadinessReady    = "READY"
+	ReadinessNotReady = "NOT_READY"
+)
+
+type EvolutionReadiness struct {
+	Verdict               string   `json:"readiness_verdict"`
+	BlockingGaps          []string `json:"blocking_gaps"`
+	ConcretePrerequisites []string `json:"concrete_prerequisites"`
+	RisksIfForced         []string `json:"risks_if_forced"`
+}
+
+func (r EvolutionReadiness) Ready() bool {
+	return r.Verdict == ReadinessReady
+}
+
+func ReviewEvolutionReadinessWithMeta(ctx context.Context, in EvolutionReadinessPromptInput) (EvolutionReadiness, string, AIResult, error) {
+	m, err := newDefaultProviderManager()
+	if err != nil {
+		return EvolutionReadiness{}, "", AIResult{}, err
+	}
+
+	prompt := BuildEvolutionReadinessPrompt(in.Canonical())
+	const maxAttempts = 3
+	var lastErr error
+	var lastMeta AIResult
+	for i := 0; i < maxAttempts; i++ {
+		res, err := m.Generate(ctx, PromptPayload{Prompt: prompt, JSON: true, Schema: EvolutionReadinessSchema})
+		if err != nil {
+			return EvolutionReadiness{}, "", AIResult{}, err
+		}
+		raw := m.normalizeJSONAnswer(ctx, &res, EvolutionReadinessSchema)
+		lastMeta = res
+		review, err := decodeEvolutionReadiness(raw)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return review, raw, res, nil
+	}
+	return EvolutionReadiness{}, "", lastMeta, lastErr
+}
+
+func decodeEvolutionReadiness(raw string) (EvolutionReadiness, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	var review EvolutionReadiness
+	if err := dec.Decode(&review); err != nil {
+		return EvolutionReadiness{}, fmt.Errorf("evolution readiness: invalid JSON: %w", err)
+	}
+	if err := dec.Decode(&struct{}{}); err == nil {
+		return EvolutionReadiness{}, fmt.Errorf("evolution readiness: invalid JSON: trailing content")
+	}
+
+	review.Verdict = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(review.Verdict), " ", "_"))
+	switch review.Verdict {
+	case ReadinessReady, ReadinessNotReady:
+	default:
+		return EvolutionReadiness{}, fmt.Errorf("evolution readiness: invalid JSON: readiness_verdict must be READY or NOT_READY")
+	}
+	if review.Verdict == ReadinessNotReady && countNonEmpty(review.BlockingGaps) == 0 {
+		return EvolutionReadiness{}, fmt.Errorf("evolution readiness: invalid JSON: blocking_gaps is required when NOT_READY")
+	}
+	if review.BlockingGaps == nil {
+		review.BlockingGaps = []string{}
+	}
+	if review.ConcretePrerequisites == nil {
+		review.ConcretePrerequisites = []string{}
+	}
+	if review.RisksIfForced == nil {
+		review.RisksIfForced = []string{}
+	}
+	return review, nil
+}
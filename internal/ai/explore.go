@@ -0,0 +1,89 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"quibit/internal/project"
+)
+
+type ExploreCandidate struct {
+	Spec     project.IdeaSpec
+	Response GenerateProjectIdeaResponse
+	Idea     ProjectIdea
+	RawJSON  string
+	Meta     AIResult
+}
+
+func expandIdeaSpec(ctx context.Context, m *ProviderManager, spec project.IdeaSpec) (ExploreCandidate, error) {
+	spec = spec.Canonical()
+	prompt := BuildConstrainedExpansionPrompt(ConstrainedExpansionPromptInput{IdeaSpec: spec})
+	res, err := m.Generate(ctx, PromptPayload{Prompt: prompt})
+	if err != nil {
+		return ExploreCandidate{}, err
+	}
+
+	resp, err := NormalizeGenerateProjectIdeaResponse(res.Text)
+	if err != nil {
+		return ExploreCandidate{Spec: spec, Meta: res}, fmt.Errorf("explore: %w", err)
+	}
+	idea := resp.ProjectIdea(spec)
+	raw, err := json.Marshal(idea)
+	if err != nil {
+		return ExploreCandidate{Spec: spec, Meta: res}, fmt.Errorf("explore: marshal idea: %w", err)
+	}
+	return ExploreCandidate{
+		Spec:     spec,
+		Response: resp,
+		Idea:     idea,
+		RawJSON:  string(raw),
+		Meta:     res,
+	}, nil
+}
+
+// ProjectIdea maps the constrained-expansion shape onto the stored ProjectIdea
+// so explored candidates can be browsed, continued and exported like any other.
+func (r GenerateProjectIdeaResponse) ProjectIdea(spec project.IdeaSpec) ProjectIdea {
+	ov := r.Overview
+	detail := strings.TrimSpace(r.TechStack.Justification)
+	if axis := strings.TrimSpace(spec.ArchitecturalAxis); axis != "" {
+		detail = strings.TrimSpace("Architecture: " + axis + ". " + detail)
+	}
+	if len(r.EngineeringFocus) > 0 {
+		detail = strings.TrimSpace(detail + " Engineering focus: " + strings.Join(r.EngineeringFocus, "; ") + ".")
+	}
+	return ProjectIdea{Project: ProjectIdeaProject{
+		Name:    ov.ProjectName,
+		Tagline: ov.Tagline,
+		Description: ProjectDescription{
+			Summary:             ov.Problem,
+			DetailedExplanation: detail,
+		},
+		Problem: ProjectProblem{
+			Problem: ov.Problem,
+		},
+		TargetUsers: ProjectTargetUsers{
+			Primary: ov.TargetUsers,
+		},
+		ValueProp: ProjectValueProp{
+			KeyBenefits: ov.SuccessMetrics,
+		},
+		MVP: ProjectMVP{
+			Goal:       r.MVPScope.Goal,
+			MustHave:   r.MVPScope.MustHaveFeatures,
+			NiceToHave: r.MVPScope.NiceToHaveFeatures,
+			OutOfScope: r.MVPScope.OutOfScope,
+		},
+		TechStack: ProjectTechStack{
+			Backend:       r.TechStack.Backend,
+			Frontend:      r.TechStack.Frontend,
+			Database:      r.TechStack.Database,
+			Infra:         r.TechStack.Infra,
+			Justification: r.TechStack.Justification,
+		},
+		Complexity: spec.ComplexityLevel,
+		Learning:   r.LearningOutcomes,
+	}}
+}
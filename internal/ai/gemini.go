@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -11,12 +12,21 @@ import (
 	"quibit/internal/model"
 )
 
+var ErrQualityGateFailed = errors.New("quality gate failed")
+
+func init() {
+	RegisterProvider("gemini", func(cfg config.AIConfig) (AIProvider, error) {
+		return NewGeminiProvider(cfg), nil
+	}, diagnoseGemini)
+}
+
 type GeminiProvider struct {
-	apiKey string
+	apiKey     string
+	structured bool
 }
 
 func NewGeminiProvider(cfg config.AIConfig) *GeminiProvider {
-	return &GeminiProvider{apiKey: cfg.GeminiAPIKey}
+	return &GeminiProvider{apiKey: cfg.GeminiAPIKey, structured: cfg.StructuredOutput}
 }
 
 func (p *GeminiProvider) Name() string { return "gemini" }
@@ -42,7 +52,12 @@ func (p *GeminiProvider) Generate(ctx context.Context, prompt PromptPayload) (AI
 		return AIResult{}, err
 	}
 
-	text, err := g.GenerateText(ctx, prompt.Prompt)
+	var text string
+	if p.structured && (prompt.JSON || prompt.Schema != nil) {
+		text, err = g.GenerateJSON(ctx, prompt.Prompt, prompt.Schema)
+	} else {
+		text, err = g.GenerateText(ctx, prompt.Prompt)
+	}
 	if err != nil {
 		return AIResult{}, err
 	}
@@ -60,19 +75,50 @@ func (p staticErrorProvider) Generate(ctx context.Context, prompt PromptPayload)
 	return AIResult{}, p.err
 }
 
-func newDefaultProviderManager() (*ProviderManager, error) {
-	cfg := config.LoadAIConfig()
-
-	primary := AIProvider(NewGeminiProvider(cfg))
-	var fallback AIProvider
-	hf, err := NewHuggingFaceProvider(cfg)
+// scoreIdeaQuality records the quality gate scores of an idea the user will
+// review themselves, without gating it.
+func scoreIdeaQuality(ctx context.Context, idea ProjectIdea, res *AIResult) error {
+	gate, err := newQualityGate(config.LoadAIConfig())
 	if err != nil {
-		fallback = staticErrorProvider{name: "huggingface", err: err}
-	} else {
-		fallback = hf
+		return err
 	}
+	v, err := gate.evaluate(ctx, idea)
+	if err != nil {
+		return err
+	}
+	res.Quality = &v.scores
+	return nil
+}
+
+func newDefaultProviderManager() (*ProviderManager, error) {
+	return NewProviderChain(config.LoadAIConfig())
+}
 
-	return NewProviderManager(primary, fallback)
+func diagnoseGemini(err error) Diagnosis {
+	if err == nil {
+		return genericDiagnosis(err)
+	}
+	if isRateLimitedError(err) {
+		d := Diagnosis{Summary: "Gemini rejected the request due to rate limit / quota exhaustion (HTTP 429 / RESOURCE_EXHAUSTED)."}
+		if retry := extractRetryHint(err); retry != "" {
+			d.Action = "Wait and retry (server suggested delay: " + retry + "). If this keeps happening, check Gemini API quotas/billing for the project behind GEMINI_API_KEY, or switch to another key/project/model."
+		} else {
+			d.Action = "Wait briefly and retry. If this keeps happening, check Gemini API quotas/billing for the project behind GEMINI_API_KEY, or switch to another key/project/model."
+		}
+		return d
+	}
+	d := Diagnosis{
+		Summary: "Gemini request failed.",
+		Action:  "Check GEMINI_API_KEY in your .env, confirm billing/quota, then retry.",
+	}
+	s := strings.ToLower(err.Error())
+	if strings.Contains(s, "quota") && strings.Contains(s, "exceed") {
+		d.Summary = "Gemini quota exceeded."
+	}
+	if strings.Contains(s, "unauthorized") || strings.Contains(s, "permission") || strings.Contains(s, "api key") {
+		d.Summary = "Gemini authentication/authorization issue (API key missing/invalid or project permission)."
+	}
+	return d
 }
 
 func GenerateProjectIdea(ctx context.Context, in model.ProjectInput) (ProjectIdea, string, error) {
@@ -87,16 +133,19 @@ func GenerateProjectIdeaOnceWithMeta(ctx context.Context, in model.ProjectInput)
 	}
 
 	prompt := BuildProjectIdeaPrompt(in)
-	res, err := m.Generate(ctx, PromptPayload{Prompt: prompt})
+	res, err := m.Generate(ctx, PromptPayload{Prompt: prompt, JSON: true, Schema: ProjectIdeaSchema})
 	if err != nil {
 		return ProjectIdea{}, "", AIResult{}, err
 	}
 
-	raw := normalizePromptContractJSON(res.Text)
+	raw := m.normalizeJSONAnswer(ctx, &res, ProjectIdeaSchema)
 	idea, err := decodeProjectIdea(raw, in)
 	if err != nil {
 		return ProjectIdea{}, "", res, err
 	}
+	if err := scoreIdeaQuality(ctx, idea, &res); err != nil {
+		return ProjectIdea{}, "", res, err
+	}
 	return idea, raw, res, nil
 }
 
@@ -106,6 +155,11 @@ func GenerateProjectIdeaWithMeta(ctx context.Context, in model.ProjectInput) (Pr
 		return ProjectIdea{}, "", AIResult{}, err
 	}
 
+	gate, err := newQualityGate(config.LoadAIConfig())
+	if err != nil {
+		return ProjectIdea{}, "", AIResult{}, err
+	}
+
 	const maxQualityAttempts = 4
 	var lastErr error
 	var lastMeta AIResult
@@ -113,18 +167,7 @@ func GenerateProjectIdeaWithMeta(ctx context.Context, in model.ProjectInput) (Pr
 	for attempt := 0; attempt < maxQualityAttempts; attempt++ {
 		prompt := BuildProjectIdeaPrompt(in)
 		if attempt > 0 {
-			strategy := rotatePivotStrategy(attempt)
-			if lastVerdict != nil {
-				switch lastVerdict.decision {
-				case qualityRefine:
-					strategy = PivotRefineDepth
-				case qualityPivot:
-					strategy = PivotContextShift
-				case qualityRegenerate:
-					strategy = rotatePivotStrategy(attempt)
-				}
-			}
-			prompt = BuildProjectIdeaPivotPrompt(in, RetryQualityTooGeneric, strategy)
+			prompt = BuildProjectIdeaPivotPrompt(in, RetryQualityTooGeneric, retryStrategy(lastVerdict, attempt), AvoidList{})
 		}
 
 		idea, raw, meta, err := generateProjectIdeaWithPrompt(ctx, m, prompt, in)
@@ -134,52 +177,64 @@ func GenerateProjectIdeaWithMeta(ctx context.Context, in model.ProjectInput) (Pr
 			continue
 		}
 
-		v := evaluateIdeaQuality(idea)
+		v, err := gate.evaluate(ctx, idea)
+		if err != nil {
+			return ProjectIdea{}, "", meta, err
+		}
 		if v.ok() {
+			meta.Quality = &v.scores
 			return idea, raw, meta, nil
 		}
 
 		lastVerdict = &v
-		lastErr = fmt.Errorf("generate project idea: quality gate failed: %s", v.summary())
+		lastErr = fmt.Errorf("generate project idea: %w: %s", ErrQualityGateFailed, v.summary())
 	}
 
 	if lastErr == nil {
-		lastErr = fmt.Errorf("generate project idea: quality gate failed")
+		lastErr = fmt.Errorf("generate project idea: %w", ErrQualityGateFailed)
 	}
 	return ProjectIdea{}, "", lastMeta, lastErr
 }
 
 func GenerateProjectIdeaWithPivot(ctx context.Context, in model.ProjectInput, reason RetryReason, strategy PivotStrategy) (ProjectIdea, string, error) {
-	idea, raw, _, err := GenerateProjectIdeaWithPivotMeta(ctx, in, reason, strategy)
+	idea, raw, _, err := GenerateProjectIdeaWithPivotMeta(ctx, in, reason, strategy, AvoidList{})
 	return idea, raw, err
 }
 
-func GenerateProjectIdeaWithPivotOnceMeta(ctx context.Context, in model.ProjectInput, reason RetryReason, strategy PivotStrategy) (ProjectIdea, string, AIResult, error) {
+func GenerateProjectIdeaWithPivotOnceMeta(ctx context.Context, in model.ProjectInput, reason RetryReason, strategy PivotStrategy, avoid AvoidList, refs ...Pivot) (ProjectIdea, string, AIResult, error) {
 	m, err := newDefaultProviderManager()
 	if err != nil {
 		return ProjectIdea{}, "", AIResult{}, err
 	}
 
-	prompt := BuildProjectIdeaPivotPrompt(in, reason, strategy)
-	res, err := m.Generate(ctx, PromptPayload{Prompt: prompt})
+	prompt := BuildProjectIdeaPivotPrompt(in, reason, strategy, avoid, refs...)
+	res, err := m.Generate(ctx, PromptPayload{Prompt: prompt, JSON: true, Schema: ProjectIdeaSchema})
 	if err != nil {
 		return ProjectIdea{}, "", AIResult{}, err
 	}
 
-	raw := normalizePromptContractJSON(res.Text)
+	raw := m.normalizeJSONAnswer(ctx, &res, ProjectIdeaSchema)
 	idea, err := decodeProjectIdea(raw, in)
 	if err != nil {
 		return ProjectIdea{}, "", res, err
 	}
+	if err := scoreIdeaQuality(ctx, idea, &res); err != nil {
+		return ProjectIdea{}, "", res, err
+	}
 	return idea, raw, res, nil
 }
 
-func GenerateProjectIdeaWithPivotMeta(ctx context.Context, in model.ProjectInput, reason RetryReason, strategy PivotStrategy) (ProjectIdea, string, AIResult, error) {
+func GenerateProjectIdeaWithPivotMeta(ctx context.Context, in model.ProjectInput, reason RetryReason, strategy PivotStrategy, avoid AvoidList, refs ...Pivot) (ProjectIdea, string, AIResult, error) {
 	m, err := newDefaultProviderManager()
 	if err != nil {
 		return ProjectIdea{}, "", AIResult{}, err
 	}
 
+	gate, err := newQualityGate(config.LoadAIConfig())
+	if err != nil {
+		return ProjectIdea{}, "", AIResult{}, err
+	}
+
 	const maxQualityAttempts = 4
 	var lastErr error
 	var lastMeta AIResult
@@ -187,20 +242,9 @@ func GenerateProjectIdeaWithPivotMeta(ctx context.Context, in model.ProjectInput
 	for attempt := 0; attempt < maxQualityAttempts; attempt++ {
 		var prompt string
 		if attempt == 0 {
-			prompt = BuildProjectIdeaPivotPrompt(in, reason, strategy)
+			prompt = BuildProjectIdeaPivotPrompt(in, reason, strategy, avoid, refs...)
 		} else {
-			nextStrategy := rotatePivotStrategy(attempt)
-			if lastVerdict != nil {
-				switch lastVerdict.decision {
-				case qualityRefine:
-					nextStrategy = PivotRefineDepth
-				case qualityPivot:
-					nextStrategy = PivotContextShift
-				case qualityRegenerate:
-					nextStrategy = rotatePivotStrategy(attempt)
-				}
-			}
-			prompt = BuildProjectIdeaPivotPrompt(in, RetryQualityTooGeneric, nextStrategy)
+			prompt = BuildProjectIdeaPivotPrompt(in, RetryQualityTooGeneric, retryStrategy(lastVerdict, attempt), avoid, refs...)
 		}
 
 		idea, raw, meta, err := generateProjectIdeaWithPrompt(ctx, m, prompt, in)
@@ -210,16 +254,20 @@ func GenerateProjectIdeaWithPivotMeta(ctx context.Context, in model.ProjectInput
 			continue
 		}
 
-		v := evaluateIdeaQuality(idea)
+		v, err := gate.evaluate(ctx, idea)
+		if err != nil {
+			return ProjectIdea{}, "", meta, err
+		}
 		if v.ok() {
+			meta.Quality = &v.scores
 			return idea, raw, meta, nil
 		}
 		lastVerdict = &v
-		lastErr = fmt.Errorf("generate project idea: quality gate failed: %s", v.summary())
+		lastErr = fmt.Errorf("generate project idea: %w: %s", ErrQualityGateFailed, v.summary())
 	}
 
 	if lastErr == nil {
-		lastErr = fmt.Errorf("generate project idea: quality gate failed")
+		lastErr = fmt.Errorf("generate project idea: %w", ErrQualityGateFailed)
 	}
 	return ProjectIdea{}, "", lastMeta, lastErr
 }
@@ -229,6 +277,20 @@ func GenerateProjectEvolution(ctx context.Context, in EvolutionInput) (ProjectEv
 	return evo, raw, err
 }
 
+// retryStrategy picks the pivot for a quality-gate retry from the last
+// verdict, rotating through the strategies when there is none to go on.
+func retryStrategy(last *qualityVerdict, attempt int) PivotStrategy {
+	if last != nil {
+		switch last.decision {
+		case qualityRefine:
+			return PivotRefineDepth
+		case qualityPivot:
+			return PivotContextShift
+		}
+	}
+	return rotatePivotStrategy(attempt)
+}
+
 func rotatePivotStrategy(attempt int) PivotStrategy {
 	switch attempt % 3 {
 	case 1:
@@ -246,12 +308,12 @@ func GenerateProjectEvolutionWithMeta(ctx context.Context, in EvolutionInput) (P
 		return ProjectEvolution{}, "", AIResult{}, err
 	}
 
-	res, err := m.Generate(ctx, PromptPayload{Prompt: BuildProjectEvolutionPrompt(in)})
+	res, err := m.Generate(ctx, PromptPayload{Prompt: BuildProjectEvolutionPrompt(in), JSON: true, Schema: ProjectEvolutionSchema})
 	if err != nil {
 		return ProjectEvolution{}, "", AIResult{}, err
 	}
 
-	raw := normalizePromptContractJSON(res.Text)
+	raw := m.normalizeJSONAnswer(ctx, &res, ProjectEvolutionSchema)
 	evo, err := decodeProjectEvolution(raw)
 	if err != nil {
 		return ProjectEvolution{}, "", AIResult{}, err
@@ -317,13 +379,13 @@ func generateProjectIdeaWithPrompt(ctx context.Context, m *ProviderManager, prom
 	var lastErr error
 	var lastMeta AIResult
 	for i := 0; i < maxAttempts; i++ {
-		res, err := m.Generate(ctx, PromptPayload{Prompt: prompt})
+		res, err := m.Generate(ctx, PromptPayload{Prompt: prompt, JSON: true, Schema: ProjectIdeaSchema})
 		if err != nil {
 			lastErr = err
 			continue
 		}
+		raw := m.normalizeJSONAnswer(ctx, &res, ProjectIdeaSchema)
 		lastMeta = res
-		raw := normalizePromptContractJSON(res.Text)
 		idea, err := decodeProjectIdea(raw, in)
 		if err != nil {
 			lastErr = err
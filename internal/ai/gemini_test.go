@@ -0,0 +1,127 @@
+package ai
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"quibit/internal/domain"
+	"quibit/internal/model"
+)
+
+// testInput matches the constraints the testdata ideas were written for.
+var testInput = model.ProjectInput{
+	AppType:    "cli",
+	Complexity: "intermediate",
+	Database:   []string{"SQLite"},
+	Goal:       "Portfolio",
+	Timeframe:  "2-4 weeks",
+}
+
+// useReplayFixtures points the provider chain at a replay directory that
+// answers each prompt with the contents of its testdata file.
+func useReplayFixtures(t *testing.T, fixtures map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	for prompt, fixture := range fixtures {
+		data, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, PromptFixtureKey(prompt)+".txt"), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("QUIBIT_PROVIDERS", "replay")
+	t.Setenv("QUIBIT_REPLAY_DIR", dir)
+	t.Setenv("QUIBIT_REPLAY_MODE", "")
+	t.Setenv("QUIBIT_JUDGE", "")
+	t.Setenv("QUIBIT_JUDGE_PROVIDERS", "")
+	t.Setenv("QUIBIT_QUALITY_RULES", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+}
+
+func TestGenerateProjectIdeaWithMeta(t *testing.T) {
+	useReplayFixtures(t, map[string]string{
+		BuildProjectIdeaPrompt(testInput): "testdata/project_idea.json",
+	})
+
+	idea, raw, meta, err := GenerateProjectIdeaWithMeta(context.Background(), testInput)
+	if err != nil {
+		t.Fatalf("GenerateProjectIdeaWithMeta: %v", err)
+	}
+	if got, want := idea.Project.Name, "Ledgerline Replay"; got != want {
+		t.Errorf("name = %q, want %q", got, want)
+	}
+	if raw == "" {
+		t.Error("raw JSON is empty")
+	}
+	if meta.ProviderUsed != "replay" || meta.FallbackUsed {
+		t.Errorf("provider = %q fallback = %v, want replay without fallback", meta.ProviderUsed, meta.FallbackUsed)
+	}
+	if meta.Quality == nil || meta.Quality.Heuristic <= 0 {
+		t.Errorf("quality scores = %+v, want the accepted answer's scores", meta.Quality)
+	}
+}
+
+func TestGenerateProjectIdeaWithMetaRetriesQualityGateFailure(t *testing.T) {
+	useReplayFixtures(t, map[string]string{
+		// The first answer is a plain todo app the ruleset hard fails, so the
+		// gate asks for a regeneration with the attempt's rotated strategy.
+		BuildProjectIdeaPrompt(testInput): "testdata/project_idea_generic.json",
+		BuildProjectIdeaPivotPrompt(testInput, RetryQualityTooGeneric, rotatePivotStrategy(1), AvoidList{}): "testdata/project_idea.json",
+	})
+
+	generic, err := os.ReadFile("testdata/project_idea_generic.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := decodeProjectIdea(string(generic), testInput)
+	if err != nil {
+		t.Fatalf("generic fixture must pass validation so only the gate rejects it: %v", err)
+	}
+	rules, err := LoadQualityRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := evaluateIdeaQuality(rules, first); v.ok() {
+		t.Fatal("generic fixture passed the quality gate")
+	}
+
+	idea, _, meta, err := GenerateProjectIdeaWithMeta(context.Background(), testInput)
+	if err != nil {
+		t.Fatalf("GenerateProjectIdeaWithMeta: %v", err)
+	}
+	if got, want := idea.Project.Name, "Ledgerline Replay"; got != want {
+		t.Errorf("name = %q, want %q from the regenerated answer", got, want)
+	}
+	if meta.Quality == nil {
+		t.Error("quality scores are missing for the regenerated answer")
+	}
+}
+
+func TestGenerateProjectIdeaWithPivotMeta(t *testing.T) {
+	ref := BuildPivot("features", domain.Project{
+		Title:            "Shelf Ledger",
+		ProblemStatement: "Home cooks lose track of pantry stock.",
+		CoreFeatures:     []string{"stock list", "expiry alerts"},
+	})
+	prompt := BuildProjectIdeaPivotPrompt(testInput, RetrySimilarityTooHigh, PivotFeatureReplacement, AvoidList{}, ref)
+	if !strings.Contains(prompt, "Dominant similarity: features") || !strings.Contains(prompt, "- title: Shelf Ledger") {
+		t.Fatalf("pivot prompt does not name the reference project:\n%s", prompt)
+	}
+	useReplayFixtures(t, map[string]string{prompt: "testdata/project_idea.json"})
+
+	idea, _, meta, err := GenerateProjectIdeaWithPivotMeta(context.Background(), testInput, RetrySimilarityTooHigh, PivotFeatureReplacement, AvoidList{}, ref)
+	if err != nil {
+		t.Fatalf("GenerateProjectIdeaWithPivotMeta: %v", err)
+	}
+	if got, want := idea.Project.Name, "Ledgerline Replay"; got != want {
+		t.Errorf("name = %q, want %q", got, want)
+	}
+	if meta.ProviderUsed != "replay" {
+		t.Errorf("provider = %q, want replay", meta.ProviderUsed)
+	}
+}
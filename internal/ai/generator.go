@@ -64,6 +64,20 @@ func NewGenerator(client *genai.Client) (*Generator, error) {
 }
 
 func (g *Generator) GenerateText(ctx context.Context, prompt string) (string, error) {
+	return g.generateContent(ctx, prompt, nil)
+}
+
+// GenerateJSON asks for an application/json response, constrained to schema
+// when it is non-nil.
+func (g *Generator) GenerateJSON(ctx context.Context, prompt string, schema *ResponseSchema) (string, error) {
+	cfg := &genai.GenerateContentConfig{ResponseMIMEType: "application/json"}
+	if schema != nil {
+		cfg.ResponseSchema = schema.Root.genaiSchema()
+	}
+	return g.generateContent(ctx, prompt, cfg)
+}
+
+func (g *Generator) generateContent(ctx context.Context, prompt string, cfg *genai.GenerateContentConfig) (string, error) {
 	if g == nil || g.client == nil {
 		return "", fmt.Errorf("generate text: client is nil")
 	}
@@ -74,7 +88,7 @@ func (g *Generator) GenerateText(ctx context.Context, prompt string) (string, er
 			Parts: []*genai.Part{{
 				Text: prompt,
 			}},
-		}}, nil)
+		}}, cfg)
 		if err != nil {
 			if i < len(modelCandidates)-1 && isOverloadedError(err) {
 				continue
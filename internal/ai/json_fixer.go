@@ -0,0 +1,270 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// fixJSON repairs the defects models commonly produce in almost-JSON:
+// trailing commas, raw newlines and control characters inside strings,
+// single-quoted strings, unquoted keys, Python literals, comments, prose
+// after the object and output cut off mid-value. It reports whether the
+// result is valid JSON.
+func fixJSON(raw string) (string, bool) {
+	start := strings.IndexAny(raw, "{[")
+	if start < 0 {
+		return "", false
+	}
+	f := &jsonFixer{src: raw[start:]}
+	out := f.run()
+	if json.Valid([]byte(out)) {
+		return out, true
+	}
+	return "", false
+}
+
+type jsonFixer struct {
+	src string
+	pos int
+	out strings.Builder
+
+	// stack holds '{' or '[' per open container; expectKey is parallel to it
+	// and tells whether the next string in an object is a key.
+	stack     []byte
+	expectKey []bool
+
+	// safe is the last point where the output ended on a complete value;
+	// truncated input is cut back to it when closing naively is not enough.
+	safe      int
+	safeStack []byte
+}
+
+func (f *jsonFixer) run() string {
+	for f.pos < len(f.src) {
+		c := f.src[f.pos]
+		switch {
+		case c == '"' || c == '\'':
+			f.pos++
+			closed := f.readString(c)
+			if !closed {
+				return f.finish(true)
+			}
+			f.afterString()
+		case c == '{' || c == '[':
+			f.pos++
+			f.out.WriteByte(c)
+			f.stack = append(f.stack, c)
+			f.expectKey = append(f.expectKey, c == '{')
+			f.markSafe()
+		case c == '}' || c == ']':
+			f.pos++
+			if len(f.stack) == 0 {
+				continue
+			}
+			f.dropTrailingComma()
+			f.out.WriteByte(closerFor(f.stack[len(f.stack)-1]))
+			f.pop()
+			if len(f.stack) == 0 {
+				return f.out.String()
+			}
+			f.markSafe()
+		case c == ',':
+			f.pos++
+			f.dropTrailingComma()
+			f.out.WriteByte(',')
+			if f.inObject() {
+				f.expectKey[len(f.expectKey)-1] = true
+			}
+		case c == ':':
+			f.pos++
+			f.out.WriteByte(':')
+			if f.inObject() {
+				f.expectKey[len(f.expectKey)-1] = false
+			}
+		case c == '/' && f.skipComment():
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			f.pos++
+			f.out.WriteByte(c)
+		default:
+			f.readBareword()
+		}
+	}
+	return f.finish(false)
+}
+
+// readString copies a string opened with quote, normalizing it to a valid
+// double-quoted JSON string. It reports whether the closing quote was found.
+func (f *jsonFixer) readString(quote byte) bool {
+	f.out.WriteByte('"')
+	for f.pos < len(f.src) {
+		c := f.src[f.pos]
+		f.pos++
+		switch {
+		case c == quote && f.closesString():
+			f.out.WriteByte('"')
+			return true
+		case c == '\\':
+			if f.pos >= len(f.src) {
+				return false
+			}
+			n := f.src[f.pos]
+			f.pos++
+			switch {
+			case n == '\'':
+				f.out.WriteByte('\'')
+			case strings.IndexByte(`"\/bfnrtu`, n) >= 0:
+				f.out.WriteByte('\\')
+				f.out.WriteByte(n)
+			default:
+				f.out.WriteString(`\\`)
+				f.out.WriteByte(n)
+			}
+		case c == '"':
+			f.out.WriteString(`\"`)
+		case c == '\n':
+			f.out.WriteString(`\n`)
+		case c == '\r':
+			f.out.WriteString(`\r`)
+		case c == '\t':
+			f.out.WriteString(`\t`)
+		case c < 0x20:
+			fmt.Fprintf(&f.out, `\u%04x`, c)
+		default:
+			f.out.WriteByte(c)
+		}
+	}
+	return false
+}
+
+// closesString reports whether the quote just read ends the string. A quote
+// followed by anything other than a delimiter is taken as an unescaped quote
+// (or apostrophe) inside the text.
+func (f *jsonFixer) closesString() bool {
+	rest := strings.TrimLeft(f.src[f.pos:], " \t\r\n")
+	return rest == "" || strings.IndexByte(",:}]", rest[0]) >= 0
+}
+
+func (f *jsonFixer) afterString() {
+	if f.inObject() && f.expectKey[len(f.expectKey)-1] {
+		return
+	}
+	f.markSafe()
+}
+
+// readBareword handles numbers, literals and unquoted object keys.
+func (f *jsonFixer) readBareword() {
+	end := f.pos
+	for end < len(f.src) && isBarewordByte(f.src[end]) {
+		end++
+	}
+	if end == f.pos {
+		// Not something JSON can hold outside a string; drop it.
+		f.pos++
+		return
+	}
+	word := f.src[f.pos:end]
+	f.pos = end
+	if f.inObject() && f.expectKey[len(f.expectKey)-1] {
+		f.out.WriteString(`"` + word + `"`)
+		return
+	}
+	switch word {
+	case "True":
+		word = "true"
+	case "False":
+		word = "false"
+	case "None", "undefined", "NaN":
+		word = "null"
+	}
+	f.out.WriteString(word)
+	if f.pos < len(f.src) {
+		f.markSafe()
+	}
+}
+
+func isBarewordByte(c byte) bool {
+	return c == '_' || c == '-' || c == '+' || c == '.' ||
+		(c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func (f *jsonFixer) skipComment() bool {
+	rest := f.src[f.pos:]
+	switch {
+	case strings.HasPrefix(rest, "//"):
+		if i := strings.IndexByte(rest, '\n'); i >= 0 {
+			f.pos += i
+		} else {
+			f.pos = len(f.src)
+		}
+		return true
+	case strings.HasPrefix(rest, "/*"):
+		if i := strings.Index(rest[2:], "*/"); i >= 0 {
+			f.pos += i + 4
+		} else {
+			f.pos = len(f.src)
+		}
+		return true
+	default:
+		f.pos++
+		return true
+	}
+}
+
+// finish closes whatever the input left open. If the naive close is not
+// valid JSON, the output is cut back to the last complete value.
+func (f *jsonFixer) finish(inString bool) string {
+	out := f.out.String()
+	if inString {
+		out += `"`
+	}
+	if closed := closeJSON(out, f.stack); json.Valid([]byte(closed)) {
+		return closed
+	}
+	return closeJSON(f.out.String()[:f.safe], f.safeStack)
+}
+
+func closeJSON(out string, stack []byte) string {
+	out = strings.TrimRight(out, " \t\r\n")
+	out = strings.TrimSuffix(out, ",")
+	if strings.HasSuffix(out, ":") {
+		out += "null"
+	}
+	var b strings.Builder
+	b.WriteString(out)
+	for i := len(stack) - 1; i >= 0; i-- {
+		b.WriteByte(closerFor(stack[i]))
+	}
+	return b.String()
+}
+
+func (f *jsonFixer) markSafe() {
+	f.safe = f.out.Len()
+	f.safeStack = append(f.safeStack[:0], f.stack...)
+}
+
+func (f *jsonFixer) dropTrailingComma() {
+	s := f.out.String()
+	trimmed := strings.TrimRight(s, " \t\r\n")
+	if strings.HasSuffix(trimmed, ",") {
+		f.out.Reset()
+		f.out.WriteString(trimmed[:len(trimmed)-1])
+		f.out.WriteString(s[len(trimmed):])
+	}
+}
+
+func (f *jsonFixer) inObject() bool {
+	return len(f.stack) > 0 && f.stack[len(f.stack)-1] == '{'
+}
+
+func (f *jsonFixer) pop() {
+	f.stack = f.stack[:len(f.stack)-1]
+	f.expectKey = f.expectKey[:len(f.expectKey)-1]
+}
+
+func closerFor(open byte) byte {
+	if open == '{' {
+		return '}'
+	}
+	return ']'
+}
@@ -0,0 +1,60 @@
+package ai
+
+import "testing"
+
+func TestFixJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"trailing commas", `{"a": 1, "b": [1, 2,],}`, `{"a": 1, "b": [1, 2]}`},
+		{"raw newline in string", "{\"a\": \"line one\nline two\"}", `{"a": "line one\nline two"}`},
+		{"single quotes with apostrophe", `{'name': 'Ana's app', 'ok': True}`, `{"name": "Ana's app", "ok": true}`},
+		{"unquoted keys and comment", `{name: 'x', n: None} // trailing`, `{"name": "x", "n": null}`},
+		{"truncated array", `{"items": ["a", "b"`, `{"items": ["a", "b"]}`},
+		{"truncated string", `{"items": ["a", "b", "unfinished str`, `{"items": ["a", "b", "unfinished str"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := fixJSON(tt.in)
+			if !ok || got != tt.want {
+				t.Errorf("fixJSON(%q) = %q, %v; want %q, true", tt.in, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixJSONUnrepairable(t *testing.T) {
+	for _, in := range []string{
+		`{"a": "x" "b": "y"}`,
+		`+1`,
+	} {
+		if got, ok := fixJSON(in); ok {
+			t.Errorf("fixJSON(%q) = %q, want no repair", in, got)
+		}
+	}
+}
+
+func TestRepairJSONText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+		path JSONRepair
+	}{
+		{"valid", `{"a":1}`, `{"a":1}`, JSONRepairNone},
+		{"fenced with prose", "Here you go:\n```json\n{\"a\":1}\n```\nThanks", `{"a":1}`, JSONRepairExtracted},
+		{"fixed locally", `{"a":1,}`, `{"a":1}`, JSONRepairLocal},
+		{"not json", `nope`, `nope`, JSONRepairFailed},
+		{"empty", "  ", "", JSONRepairFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, path := repairJSONText(tt.in)
+			if got != tt.want || path != tt.path {
+				t.Errorf("repairJSONText(%q) = %q, %v; want %q, %v", tt.in, got, path, tt.want, tt.path)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+)
+
+// JSONRepair names how a model answer became valid JSON.
+type JSONRepair string
+
+const (
+	// JSONRepairNone: the answer was valid JSON as returned.
+	JSONRepairNone JSONRepair = "none"
+	// JSONRepairExtracted: the object was cut out of code fences or prose.
+	JSONRepairExtracted JSONRepair = "extracted"
+	// JSONRepairLocal: the lexer-based fixer repaired the syntax.
+	JSONRepairLocal JSONRepair = "local"
+	// JSONRepairModel: a follow-up model call rewrote the answer.
+	JSONRepairModel JSONRepair = "model"
+	// JSONRepairFailed: nothing produced valid JSON; the raw text is passed on.
+	JSONRepairFailed JSONRepair = "failed"
+)
+
+// maxRepairInput caps how much of a broken answer is sent back to the model.
+const maxRepairInput = 24000
+
+var (
+	jsonRepairMu     sync.Mutex
+	jsonRepairCounts = map[JSONRepair]int64{}
+)
+
+func countJSONRepair(path JSONRepair) {
+	jsonRepairMu.Lock()
+	jsonRepairCounts[path]++
+	jsonRepairMu.Unlock()
+}
+
+// TakeJSONRepairCounts returns how often each repair path was used since the
+// last call and resets the counters.
+func TakeJSONRepairCounts() map[JSONRepair]int64 {
+	jsonRepairMu.Lock()
+	defer jsonRepairMu.Unlock()
+	out := maps.Clone(jsonRepairCounts)
+	clear(jsonRepairCounts)
+	return out
+}
+
+// normalizeJSONAnswer turns res.Text into JSON with canonical keys. Syntax the
+// local fixer cannot repair is sent back to the chain once, constrained to
+// schema, unless model repair is disabled. The path taken is recorded in
+// res.JSONRepair and in the process-wide counters.
+func (m *ProviderManager) normalizeJSONAnswer(ctx context.Context, res *AIResult, schema *ResponseSchema) string {
+	text, path := repairJSONText(res.Text)
+	if path == JSONRepairFailed && m.modelRepair && strings.TrimSpace(res.Text) != "" {
+		if fixed, err := m.repairJSONWithModel(ctx, res.Text, schema); err == nil {
+			text, path = fixed, JSONRepairModel
+		}
+	}
+	res.JSONRepair = path
+	countJSONRepair(path)
+	return canonicalizeJSONKeys(text)
+}
+
+func (m *ProviderManager) repairJSONWithModel(ctx context.Context, broken string, schema *ResponseSchema) (string, error) {
+	prompt, err := BuildJSONRepairPrompt(broken, schema)
+	if err != nil {
+		return "", err
+	}
+	res, err := m.Generate(ctx, PromptPayload{Prompt: prompt, JSON: true, Schema: schema})
+	if err != nil {
+		return "", err
+	}
+	fixed, path := repairJSONText(res.Text)
+	if path == JSONRepairFailed {
+		return "", fmt.Errorf("json repair: model answer is still not valid JSON")
+	}
+	return fixed, nil
+}
+
+// BuildJSONRepairPrompt asks for broken to be rewritten as valid JSON that
+// matches schema, keeping the content.
+func BuildJSONRepairPrompt(broken string, schema *ResponseSchema) (string, error) {
+	if len(broken) > maxRepairInput {
+		broken = broken[:maxRepairInput]
+	}
+	var b strings.Builder
+	b.WriteString("The text below was meant to be a single JSON object but is not valid JSON.\n")
+	b.WriteString("Rewrite it as valid JSON. Keep every value that is present; do not invent, summarize or translate content.\n")
+	b.WriteString("Complete truncated strings and arrays minimally. Output ONLY the JSON object, no markdown and no commentary.\n")
+	if schema != nil && schema.Root != nil {
+		s, err := json.MarshalIndent(schema.Root, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("json repair: marshal schema: %w", err)
+		}
+		b.WriteString("\nThe JSON must match this JSON Schema (")
+		b.WriteString(schema.Name)
+		b.WriteString("):\n")
+		b.Write(s)
+		b.WriteString("\n")
+	}
+	b.WriteString("\nBroken JSON:\n")
+	b.WriteString(broken)
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+// repairJSONText is the local part of the pipeline: the text as-is, then the
+// object extracted from fences or prose, then the lexer-based fixer.
+func repairJSONText(raw string) (string, JSONRepair) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return raw, JSONRepairFailed
+	}
+	if json.Valid([]byte(raw)) {
+		return raw, JSONRepairNone
+	}
+	if obj := extractJSONObject(raw); obj != "" && json.Valid([]byte(obj)) {
+		return obj, JSONRepairExtracted
+	}
+	if fixed, ok := fixJSON(stripCodeFences(raw)); ok {
+		return fixed, JSONRepairLocal
+	}
+	return raw, JSONRepairFailed
+}
@@ -11,61 +11,95 @@ import (
 	"google.golang.org/genai"
 )
 
+var ErrProvidersFailed = errors.New("ai manager: generation failed")
+
 type ProviderManager struct {
-	primary  AIProvider
-	fallback AIProvider
+	providers []AIProvider
+	limits    map[string]int
+
+	// modelRepair allows a follow-up call to fix JSON the local fixer cannot.
+	modelRepair bool
+}
+
+func NewProviderManager(providers ...AIProvider) (*ProviderManager, error) {
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("ai manager: no providers")
+	}
+	for i, p := range providers {
+		if p == nil {
+			return nil, fmt.Errorf("ai manager: provider %d is nil", i+1)
+		}
+	}
+	return &ProviderManager{providers: providers}, nil
 }
 
-func NewProviderManager(primary AIProvider, fallback AIProvider) (*ProviderManager, error) {
-	if primary == nil {
-		return nil, fmt.Errorf("ai manager: primary provider is nil")
+func (m *ProviderManager) Providers() []string {
+	if m == nil {
+		return nil
 	}
-	if fallback == nil {
-		return nil, fmt.Errorf("ai manager: fallback provider is nil")
+	names := make([]string, 0, len(m.providers))
+	for _, p := range m.providers {
+		names = append(names, p.Name())
 	}
-	return &ProviderManager{primary: primary, fallback: fallback}, nil
+	return names
 }
 
 func (m *ProviderManager) Generate(ctx context.Context, prompt PromptPayload) (AIResult, error) {
 	if ctx == nil {
 		return AIResult{}, fmt.Errorf("ai manager: ctx is nil")
 	}
-	if m == nil || m.primary == nil || m.fallback == nil {
+	if m == nil || len(m.providers) == 0 {
 		return AIResult{}, fmt.Errorf("ai manager: not initialized")
 	}
 
 	start := time.Now()
 
-	res, err := m.primary.Generate(ctx, prompt)
-	if err == nil {
-		res.LatencyMS = time.Since(start).Milliseconds()
-		return res, nil
-	}
-
-	if !shouldFallbackFromPrimary(err) {
-		return AIResult{}, err
+	type attempt struct {
+		name string
+		err  error
 	}
-
-	primaryErr := err
-
-	res2, err2 := m.fallback.Generate(ctx, prompt)
-	if err2 != nil {
-		return AIResult{}, fmt.Errorf("ai manager: generation failed\n\nPrimary provider (%s)\n- Error: %s\n- Diagnosis: %s\n- What you can do: %s\n\nFallback provider (%s)\n- Error: %s\n- Diagnosis: %s\n- What you can do: %s",
-			m.primary.Name(),
-			sanitizeErr(primaryErr),
-			primaryDiagnosis(primaryErr),
-			primaryActions(primaryErr),
-			m.fallback.Name(),
-			sanitizeErr(err2),
-			fallbackDiagnosis(err2),
-			fallbackActions(err2),
+	var failed []attempt
+	for _, p := range m.providers {
+		release, err := acquireProviderSlot(ctx, p.Name(), m.limits[p.Name()])
+		if err != nil {
+			return AIResult{}, err
+		}
+		res, err := p.Generate(ctx, prompt)
+		release()
+		if ctx.Err() != nil {
+			return AIResult{}, ctx.Err()
+		}
+		if err == nil {
+			if len(failed) > 0 {
+				res.FallbackUsed = true
+				errs := make([]string, 0, len(failed))
+				for _, f := range failed {
+					errs = append(errs, f.name+": "+sanitizeErr(f.err))
+				}
+				res.ProviderError = strings.Join(errs, "\n")
+			}
+			res.LatencyMS = time.Since(start).Milliseconds()
+			return res, nil
+		}
+		if !shouldFallback(err) {
+			return AIResult{}, err
+		}
+		failed = append(failed, attempt{name: p.Name(), err: err})
+	}
+
+	var b strings.Builder
+	for i, f := range failed {
+		d := DiagnoseProviderError(f.name, f.err)
+		fmt.Fprintf(&b, "\n\nProvider %d/%d (%s)\n- Error: %s\n- Diagnosis: %s\n- What you can do: %s",
+			i+1,
+			len(m.providers),
+			f.name,
+			sanitizeErr(f.err),
+			d.Summary,
+			d.Action,
 		)
 	}
-
-	res2.FallbackUsed = true
-	res2.ProviderError = sanitizeErr(primaryErr)
-	res2.LatencyMS = time.Since(start).Milliseconds()
-	return res2, nil
+	return AIResult{}, fmt.Errorf("%w%s", ErrProvidersFailed, b.String())
 }
 
 func sanitizeErr(err error) string {
@@ -79,7 +113,7 @@ func sanitizeErr(err error) string {
 	return s
 }
 
-func shouldFallbackFromPrimary(err error) bool {
+func shouldFallback(err error) bool {
 	if err == nil {
 		return false
 	}
@@ -135,71 +169,6 @@ func isRateLimitedError(err error) bool {
 	return false
 }
 
-func primaryDiagnosis(err error) string {
-	if err == nil {
-		return "unknown"
-	}
-	if isRateLimitedError(err) {
-		return "Gemini rejected the request due to rate limit / quota exhaustion (HTTP 429 / RESOURCE_EXHAUSTED)."
-	}
-	s := strings.ToLower(err.Error())
-	if strings.Contains(s, "quota") && strings.Contains(s, "exceed") {
-		return "Gemini quota exceeded."
-	}
-	if strings.Contains(s, "unauthorized") || strings.Contains(s, "permission") || strings.Contains(s, "api key") {
-		return "Gemini authentication/authorization issue (API key missing/invalid or project permission)."
-	}
-	return "Primary provider failed."
-}
-
-func primaryActions(err error) string {
-	if err == nil {
-		return "Retry generation."
-	}
-	if isRateLimitedError(err) {
-		retry := extractRetryHint(err)
-		if retry != "" {
-			return "Wait and retry (server suggested delay: " + retry + "). If this keeps happening, check Gemini API quotas/billing for the project behind GEMINI_API_KEY, or switch to another key/project/model."
-		}
-		return "Wait briefly and retry. If this keeps happening, check Gemini API quotas/billing for the project behind GEMINI_API_KEY, or switch to another key/project/model."
-	}
-	return "Check GEMINI_API_KEY in your .env, confirm billing/quota, then retry."
-}
-
-func fallbackDiagnosis(err error) string {
-	if err == nil {
-		return "unknown"
-	}
-	s := strings.ToLower(err.Error())
-	if strings.Contains(s, "hf_token is required") {
-		return "Fallback provider is configured but HF_TOKEN is missing."
-	}
-	if strings.Contains(s, "http 503") || strings.Contains(s, "service unavailable") {
-		return "Hugging Face Router is temporarily unavailable (HTTP 503)."
-	}
-	if strings.Contains(s, "http 401") || strings.Contains(s, "http 403") {
-		return "Hugging Face authentication/authorization failure (token invalid/insufficient)."
-	}
-	return "Fallback provider failed."
-}
-
-func fallbackActions(err error) string {
-	if err == nil {
-		return "Retry generation."
-	}
-	s := strings.ToLower(err.Error())
-	if strings.Contains(s, "hf_token is required") {
-		return "Set HF_TOKEN in your .env (Hugging Face access token), then retry."
-	}
-	if strings.Contains(s, "http 503") || strings.Contains(s, "service unavailable") {
-		return "Retry after a short delay. If persistent, verify HF Router availability and ensure your HF_TOKEN is valid; you can also switch fallback provider/model if supported."
-	}
-	if strings.Contains(s, "http 401") || strings.Contains(s, "http 403") {
-		return "Verify HF_TOKEN is correct and has access; then retry."
-	}
-	return "Retry. If it persists, check HF_TOKEN and network connectivity."
-}
-
 func extractRetryHint(err error) string {
 	if err == nil {
 		return ""
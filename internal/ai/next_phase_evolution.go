@@ -0,0 +1,133 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	EvolutionContractProject   = "project_evolution"
+	EvolutionContractNextPhase = "next_phase"
+)
+
+type NextPhaseEvolution struct {
+	EvolutionGoal            string            `json:"evolution_goal"`
+	ArchitecturalChanges     []string          `json:"architectural_changes"`
+	NewEngineeringConcerns   []string          `json:"new_engineering_concerns"`
+	UpdatedMVPScope          NextPhaseMVPScope `json:"updated_mvp_scope"`
+	SkillsAndConceptsLearned []string          `json:"skills_and_concepts_learned"`
+}
+
+type NextPhaseMVPScope struct {
+	MustHave   []string `json:"must_have"`
+	OutOfScope []string `json:"out_of_scope"`
+}
+
+func GenerateNextPhaseEvolutionWithMeta(ctx context.Context, in NextPhaseEvolutionPromptInput) (NextPhaseEvolution, string, AIResult, error) {
+	m, err := newDefaultProviderManager()
+	if err != nil {
+		return NextPhaseEvolution{}, "", AIResult{}, err
+	}
+
+	prompt := BuildNextPhaseEvolutionPrompt(in)
+	const maxAttempts = 3
+	var lastErr error
+	var lastMeta AIResult
+	for i := 0; i < maxAttempts; i++ {
+		res, err := m.Generate(ctx, PromptPayload{Prompt: prompt, JSON: true, Schema: NextPhaseEvolutionSchema})
+		if err != nil {
+			return NextPhaseEvolution{}, "", AIResult{}, err
+		}
+		raw := m.normalizeJSONAnswer(ctx, &res, NextPhaseEvolutionSchema)
+		lastMeta = res
+		evo, err := decodeNextPhaseEvolution(raw)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return evo, raw, res, nil
+	}
+	return NextPhaseEvolution{}, "", lastMeta, lastErr
+}
+
+func decodeNextPhaseEvolution(raw string) (NextPhaseEvolution, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	var evo NextPhaseEvolution
+	if err := dec.Decode(&evo); err != nil {
+		return NextPhaseEvolution{}, fmt.Errorf("generate next phase evolution: invalid JSON: %w", err)
+	}
+	if err := dec.Decode(&struct{}{}); err == nil {
+		return NextPhaseEvolution{}, fmt.Errorf("generate next phase evolution: invalid JSON: trailing content")
+	}
+
+	if normalizeWhitespace(evo.EvolutionGoal) == "" {
+		return NextPhaseEvolution{}, fmt.Errorf("generate next phase evolution: invalid JSON: evolution_goal is required")
+	}
+	if countNonEmpty(evo.ArchitecturalChanges) == 0 {
+		return NextPhaseEvolution{}, fmt.Errorf("generate next phase evolution: invalid JSON: architectural_changes is required")
+	}
+	if countNonEmpty(evo.NewEngineeringConcerns) < 2 {
+		return NextPhaseEvolution{}, fmt.Errorf("generate next phase evolution: invalid JSON: new_engineering_concerns needs at least 2 items")
+	}
+	if countNonEmpty(evo.UpdatedMVPScope.MustHave) == 0 {
+		return NextPhaseEvolution{}, fmt.Errorf("generate next phase evolution: invalid JSON: updated_mvp_scope.must_have is required")
+	}
+	if countNonEmpty(evo.SkillsAndConceptsLearned) == 0 {
+		return NextPhaseEvolution{}, fmt.Errorf("generate next phase evolution: invalid JSON: skills_and_concepts_learned is required")
+	}
+	if evo.UpdatedMVPScope.OutOfScope == nil {
+		evo.UpdatedMVPScope.OutOfScope = []string{}
+	}
+	return evo, nil
+}
+
+// SavedEvolution is a stored evolution decoded according to the contract it
+// was generated with.
+type SavedEvolution struct {
+	Contract  string
+	Project   *ProjectEvolution
+	NextPhase *NextPhaseEvolution
+	// Scope is the stored scope version a next-phase evolution produced, when
+	// one exists.
+	Scope *ScopeVersion
+}
+
+// ScopeVersion is the full MVP scope recorded after a next-phase evolution.
+type ScopeVersion struct {
+	MustHave   []string
+	OutOfScope []string
+}
+
+func DecodeSavedEvolution(contract string, raw string) (SavedEvolution, error) {
+	switch strings.TrimSpace(contract) {
+	case EvolutionContractNextPhase:
+		var evo NextPhaseEvolution
+		if err := json.Unmarshal([]byte(raw), &evo); err != nil {
+			return SavedEvolution{}, err
+		}
+		return SavedEvolution{Contract: EvolutionContractNextPhase, NextPhase: &evo}, nil
+	case "", EvolutionContractProject:
+		var evo ProjectEvolution
+		if err := json.Unmarshal([]byte(raw), &evo); err != nil {
+			return SavedEvolution{}, err
+		}
+		return SavedEvolution{Contract: EvolutionContractProject, Project: &evo}, nil
+	default:
+		return SavedEvolution{}, fmt.Errorf("unknown evolution contract %q", contract)
+	}
+}
+
+func (e SavedEvolution) Overview() string {
+	switch {
+	case e.NextPhase != nil:
+		return strings.TrimSpace(e.NextPhase.EvolutionGoal)
+	case e.Project != nil:
+		return strings.TrimSpace(e.Project.EvolutionOverview)
+	default:
+		return ""
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"strings"
 )
 
-func normalizePromptContractJSON(raw string) string {
+// canonicalizeJSONKeys rewrites known prompt-contract keys to their canonical
+// snake_case spelling. Text that is not valid JSON is returned unchanged.
+func canonicalizeJSONKeys(raw string) string {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
 		return raw
@@ -119,6 +121,18 @@ var knownPromptContractKeys = map[string]struct{}{
 	"proposed_enhancements": {},
 	"risk_considerations":   {},
 
+	"evolution_goal":              {},
+	"architectural_changes":       {},
+	"new_engineering_concerns":    {},
+	"updated_mvp_scope":           {},
+	"must_have":                   {},
+	"skills_and_concepts_learned": {},
+
+	"readiness_verdict":      {},
+	"blocking_gaps":          {},
+	"concrete_prerequisites": {},
+	"risks_if_forced":        {},
+
 	"title":                {},
 	"core_features":        {},
 	"mvp_scope":            {},
@@ -0,0 +1,212 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"quibit/internal/config"
+)
+
+const (
+	ollamaDefaultHost = "http://localhost:11434"
+	ollamaTimeout     = 5 * time.Minute
+)
+
+func init() {
+	RegisterProvider("ollama", func(cfg config.AIConfig) (AIProvider, error) {
+		return NewOllamaProvider(cfg)
+	}, diagnoseOllama)
+}
+
+type OllamaProvider struct {
+	baseURL  string
+	model    string
+	endpoint string
+	stream   bool
+	client   *http.Client
+}
+
+func NewOllamaProvider(cfg config.AIConfig) (*OllamaProvider, error) {
+	model := strings.TrimSpace(cfg.OllamaModel)
+	if model == "" {
+		return nil, fmt.Errorf("OLLAMA_MODEL is required")
+	}
+	endpoint := strings.TrimSpace(cfg.OllamaEndpoint)
+	switch endpoint {
+	case "":
+		endpoint = "chat"
+	case "chat", "generate":
+	default:
+		return nil, fmt.Errorf("ollama: OLLAMA_ENDPOINT must be chat|generate")
+	}
+	return &OllamaProvider{
+		baseURL:  ollamaBaseURL(cfg.OllamaHost),
+		model:    model,
+		endpoint: endpoint,
+		stream:   cfg.OllamaStream,
+		client: &http.Client{
+			Timeout: ollamaTimeout,
+		},
+	}, nil
+}
+
+// ollamaBaseURL mirrors the ollama CLI: OLLAMA_HOST may be a bare host or host:port.
+func ollamaBaseURL(host string) string {
+	host = strings.TrimRight(strings.TrimSpace(host), "/")
+	if host == "" {
+		return ollamaDefaultHost
+	}
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	if strings.HasPrefix(host, "http://") && strings.Count(host, ":") == 1 {
+		host += ":11434"
+	}
+	return host
+}
+
+func (p *OllamaProvider) Name() string { return "ollama" }
+
+func (p *OllamaProvider) Generate(ctx context.Context, prompt PromptPayload) (AIResult, error) {
+	if ctx == nil {
+		return AIResult{}, fmt.Errorf("ollama: ctx is nil")
+	}
+	if p == nil || p.client == nil {
+		return AIResult{}, fmt.Errorf("ollama: not initialized")
+	}
+	if strings.TrimSpace(prompt.Prompt) == "" {
+		return AIResult{}, fmt.Errorf("ollama: prompt is empty")
+	}
+
+	type message struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+	body := map[string]any{
+		"model":  p.model,
+		"stream": p.stream,
+	}
+	if p.endpoint == "chat" {
+		body["messages"] = []message{{Role: "user", Content: prompt.Prompt}}
+	} else {
+		body["prompt"] = prompt.Prompt
+	}
+	if prompt.JSON {
+		body["format"] = "json"
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return AIResult{}, fmt.Errorf("ollama: marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/"+p.endpoint, bytes.NewReader(b))
+	if err != nil {
+		return AIResult{}, fmt.Errorf("ollama: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/x-ndjson, application/json")
+
+	start := time.Now()
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return AIResult{}, fmt.Errorf("ollama: request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		rawBody, _ := io.ReadAll(resp.Body)
+		msg := strings.TrimSpace(string(rawBody))
+		if msg == "" {
+			msg = resp.Status
+		}
+		return AIResult{}, fmt.Errorf("ollama: http %d: %s", resp.StatusCode, msg)
+	}
+
+	text, err := readOllamaResponse(resp.Body)
+	if err != nil {
+		return AIResult{}, err
+	}
+	if text == "" {
+		return AIResult{}, fmt.Errorf("ollama: empty content")
+	}
+
+	return AIResult{
+		Text:         text,
+		ProviderUsed: p.Name(),
+		LatencyMS:    time.Since(start).Milliseconds(),
+	}, nil
+}
+
+// readOllamaResponse handles both a single JSON object and the NDJSON chunk stream.
+func readOllamaResponse(r io.Reader) (string, error) {
+	type chunk struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		Response string `json:"response"`
+		Done     bool   `json:"done"`
+		Error    string `json:"error"`
+	}
+
+	var sb strings.Builder
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var c chunk
+		if err := json.Unmarshal(line, &c); err != nil {
+			return "", fmt.Errorf("ollama: decode response: %w", err)
+		}
+		if strings.TrimSpace(c.Error) != "" {
+			return "", fmt.Errorf("ollama: %s", strings.TrimSpace(c.Error))
+		}
+		sb.WriteString(c.Message.Content)
+		sb.WriteString(c.Response)
+		if c.Done {
+			break
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", fmt.Errorf("ollama: read response: %w", err)
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+func diagnoseOllama(err error) Diagnosis {
+	if err == nil {
+		return genericDiagnosis(err)
+	}
+	s := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(s, "ollama_model is required"):
+		return Diagnosis{
+			Summary: "Ollama provider is enabled but OLLAMA_MODEL is not set.",
+			Action:  "Set OLLAMA_MODEL to a locally pulled model (see `ollama list`), then retry.",
+		}
+	case strings.Contains(s, "connection refused") || strings.Contains(s, "no such host"):
+		return Diagnosis{
+			Summary: "Ollama server is not reachable.",
+			Action:  "Start it with `ollama serve` (or check OLLAMA_HOST), then retry.",
+		}
+	case strings.Contains(s, "http 404") || (strings.Contains(s, "model") && strings.Contains(s, "not found")):
+		return Diagnosis{
+			Summary: "Ollama does not have the configured model.",
+			Action:  "Run `ollama pull <model>` for OLLAMA_MODEL, then retry.",
+		}
+	default:
+		return Diagnosis{
+			Summary: "Ollama request failed.",
+			Action:  "Retry. If it persists, check the Ollama server logs and OLLAMA_HOST/OLLAMA_MODEL.",
+		}
+	}
+}
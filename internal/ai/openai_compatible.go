@@ -0,0 +1,268 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"quibit/internal/config"
+)
+
+const openAIDefaultTimeout = 60 * time.Second
+
+func init() {
+	RegisterProvider("openai", func(cfg config.AIConfig) (AIProvider, error) {
+		oc, err := config.LoadOpenAIConfig(cfg.OpenAIConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		oc.StructuredOutput = cfg.StructuredOutput
+		return NewOpenAICompatibleProvider("openai", oc)
+	}, diagnoseOpenAI)
+}
+
+type OpenAICompatibleProvider struct {
+	name        string
+	baseURL     string
+	model       string
+	apiKey      string
+	headers     map[string]string
+	temperature *float64
+	maxTokens   int
+	structured  bool
+	client      *http.Client
+
+	// schemaRejected is set once the server refuses response_format, so
+	// later prompts go straight to the prose-only request.
+	schemaRejected atomic.Bool
+}
+
+func NewOpenAICompatibleProvider(name string, cfg config.OpenAIConfig) (*OpenAICompatibleProvider, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "openai"
+	}
+	baseURL := strings.TrimRight(strings.TrimSpace(cfg.BaseURL), "/")
+	if baseURL == "" {
+		return nil, fmt.Errorf("%s: base URL is required", name)
+	}
+	model := strings.TrimSpace(cfg.Model)
+	if model == "" {
+		return nil, fmt.Errorf("%s: OPENAI_MODEL is required", name)
+	}
+	timeout := openAIDefaultTimeout
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+	headers := make(map[string]string, len(cfg.Headers))
+	for k, v := range cfg.Headers {
+		headers[k] = v
+	}
+	return &OpenAICompatibleProvider{
+		name:        name,
+		baseURL:     baseURL,
+		model:       model,
+		apiKey:      strings.TrimSpace(cfg.APIKey),
+		headers:     headers,
+		temperature: cfg.Temperature,
+		maxTokens:   cfg.MaxTokens,
+		structured:  cfg.StructuredOutput,
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}, nil
+}
+
+func (p *OpenAICompatibleProvider) Name() string { return p.name }
+
+func (p *OpenAICompatibleProvider) Generate(ctx context.Context, prompt PromptPayload) (AIResult, error) {
+	if ctx == nil {
+		return AIResult{}, fmt.Errorf("openai: ctx is nil")
+	}
+	if p == nil || p.client == nil {
+		return AIResult{}, fmt.Errorf("openai: not initialized")
+	}
+	if strings.TrimSpace(prompt.Prompt) == "" {
+		return AIResult{}, fmt.Errorf("%s: prompt is empty", p.name)
+	}
+
+	body := openAIChatRequest{
+		Model: p.model,
+		Messages: []openAIMessage{
+			{Role: "user", Content: prompt.Prompt},
+		},
+		Temperature: p.temperature,
+		MaxTokens:   p.maxTokens,
+	}
+	if p.structured && prompt.Schema != nil && !p.schemaRejected.Load() {
+		body.ResponseFormat = &openAIResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &openAIJSONSchema{
+				Name:   prompt.Schema.Name,
+				Strict: true,
+				Schema: prompt.Schema.Root,
+			},
+		}
+	}
+
+	start := time.Now()
+	text, err := p.complete(ctx, body)
+	var httpErr *openAIHTTPError
+	if err != nil && body.ResponseFormat != nil && errors.As(err, &httpErr) &&
+		(httpErr.status == http.StatusBadRequest || httpErr.status == http.StatusUnprocessableEntity) {
+		// Not every OpenAI-compatible server or model implements
+		// json_schema; fall back to the prompt's own JSON contract.
+		p.schemaRejected.Store(true)
+		body.ResponseFormat = nil
+		text, err = p.complete(ctx, body)
+	}
+	if err != nil {
+		return AIResult{}, err
+	}
+
+	return AIResult{
+		Text:         text,
+		ProviderUsed: p.Name(),
+		LatencyMS:    time.Since(start).Milliseconds(),
+	}, nil
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model          string                `json:"model"`
+	Messages       []openAIMessage       `json:"messages"`
+	Temperature    *float64              `json:"temperature,omitempty"`
+	MaxTokens      int                   `json:"max_tokens,omitempty"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIResponseFormat struct {
+	Type       string            `json:"type"`
+	JSONSchema *openAIJSONSchema `json:"json_schema,omitempty"`
+}
+
+type openAIJSONSchema struct {
+	Name   string      `json:"name"`
+	Strict bool        `json:"strict"`
+	Schema *SchemaNode `json:"schema"`
+}
+
+type openAIHTTPError struct {
+	name   string
+	status int
+	msg    string
+}
+
+func (e *openAIHTTPError) Error() string {
+	return fmt.Sprintf("%s: http %d: %s", e.name, e.status, e.msg)
+}
+
+func (p *OpenAICompatibleProvider) complete(ctx context.Context, body openAIChatRequest) (string, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("%s: marshal request: %w", p.name, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewReader(b))
+	if err != nil {
+		return "", fmt.Errorf("%s: build request: %w", p.name, err)
+	}
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for k, v := range p.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s: request failed: %w", p.name, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	rawBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg := strings.TrimSpace(string(rawBody))
+		if msg == "" {
+			msg = resp.Status
+		}
+		return "", &openAIHTTPError{name: p.name, status: resp.StatusCode, msg: msg}
+	}
+
+	type chatResp struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	var out chatResp
+	if err := json.Unmarshal(rawBody, &out); err != nil {
+		return "", fmt.Errorf("%s: decode response: %w", p.name, err)
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("%s: empty choices", p.name)
+	}
+	text := strings.TrimSpace(out.Choices[0].Message.Content)
+	if text == "" {
+		return "", fmt.Errorf("%s: empty content", p.name)
+	}
+	return text, nil
+}
+
+func diagnoseOpenAI(err error) Diagnosis {
+	if err == nil {
+		return genericDiagnosis(err)
+	}
+	s := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(s, "openai config"):
+		return Diagnosis{
+			Summary: "OpenAI-compatible provider configuration is invalid.",
+			Action:  "Fix QUIBIT_OPENAI_CONFIG or the OPENAI_* variables in your .env, then retry.",
+		}
+	case strings.Contains(s, "openai_model is required"):
+		return Diagnosis{
+			Summary: "OpenAI-compatible provider is enabled but no model is configured.",
+			Action:  "Set OPENAI_MODEL (or \"model\" in QUIBIT_OPENAI_CONFIG), then retry.",
+		}
+	case strings.Contains(s, "http 401") || strings.Contains(s, "http 403"):
+		return Diagnosis{
+			Summary: "OpenAI-compatible endpoint rejected the credentials.",
+			Action:  "Verify OPENAI_API_KEY (and any auth headers in OPENAI_HEADERS) for OPENAI_BASE_URL.",
+		}
+	case strings.Contains(s, "http 404"):
+		return Diagnosis{
+			Summary: "OpenAI-compatible endpoint or model was not found.",
+			Action:  "Check that OPENAI_BASE_URL ends with the API root (e.g. .../v1) and OPENAI_MODEL exists on that server.",
+		}
+	case isRateLimitedError(err):
+		return Diagnosis{
+			Summary: "OpenAI-compatible endpoint rate-limited the request (HTTP 429).",
+			Action:  "Wait briefly and retry, or check the quota of the account behind OPENAI_API_KEY.",
+		}
+	case strings.Contains(s, "connection refused") || strings.Contains(s, "no such host"):
+		return Diagnosis{
+			Summary: "OpenAI-compatible endpoint is unreachable.",
+			Action:  "Make sure the server at OPENAI_BASE_URL is running and reachable, then retry.",
+		}
+	default:
+		return Diagnosis{
+			Summary: "OpenAI-compatible request failed.",
+			Action:  "Retry. If it persists, check OPENAI_BASE_URL, OPENAI_MODEL and network connectivity.",
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"quibit/internal/config"
+)
+
+// chatServer passes every request and its decoded body to seen, and answers
+// with the status and body seen returns.
+func chatServer(t *testing.T, seen func(r *http.Request, body map[string]any) (int, string)) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		status, reply := seen(r, body)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(reply))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func chatReply(content string) string {
+	b, _ := json.Marshal(map[string]any{
+		"choices": []any{map[string]any{"message": map[string]any{"content": content}}},
+	})
+	return string(b)
+}
+
+func TestOpenAICompatibleProviderRequest(t *testing.T) {
+	var got *http.Request
+	var body map[string]any
+	srv := chatServer(t, func(r *http.Request, b map[string]any) (int, string) {
+		got, body = r, b
+		return http.StatusOK, chatReply("  {\"ok\": true}\n")
+	})
+
+	temp := 0.2
+	p, err := NewOpenAICompatibleProvider("local", config.OpenAIConfig{
+		BaseURL:     srv.URL + "/v1/",
+		APIKey:      " sk-test ",
+		Model:       "qwen2.5",
+		Headers:     map[string]string{"X-Team": "quibit"},
+		Temperature: &temp,
+		MaxTokens:   512,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := p.Generate(context.Background(), PromptPayload{Prompt: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Text != `{"ok": true}` || res.ProviderUsed != "local" {
+		t.Errorf("result = %q from %q, want the trimmed content from local", res.Text, res.ProviderUsed)
+	}
+
+	if got.Method != http.MethodPost || got.URL.Path != "/v1/chat/completions" {
+		t.Errorf("request = %s %s, want POST /v1/chat/completions", got.Method, got.URL.Path)
+	}
+	if h := got.Header.Get("Authorization"); h != "Bearer sk-test" {
+		t.Errorf("Authorization = %q", h)
+	}
+	if h := got.Header.Get("X-Team"); h != "quibit" {
+		t.Errorf("X-Team = %q", h)
+	}
+	if body["model"] != "qwen2.5" || body["temperature"] != 0.2 || body["max_tokens"] != 512.0 {
+		t.Errorf("model/temperature/max_tokens = %v/%v/%v", body["model"], body["temperature"], body["max_tokens"])
+	}
+	msgs, _ := body["messages"].([]any)
+	if len(msgs) != 1 || msgs[0].(map[string]any)["content"] != "hello" {
+		t.Errorf("messages = %v, want the prompt as one user message", body["messages"])
+	}
+}
+
+func TestOpenAICompatibleProviderOmitsUnsetOptions(t *testing.T) {
+	var got *http.Request
+	var body map[string]any
+	srv := chatServer(t, func(r *http.Request, b map[string]any) (int, string) {
+		got, body = r, b
+		return http.StatusOK, chatReply("hi")
+	})
+
+	p, err := NewOpenAICompatibleProvider("", config.OpenAIConfig{BaseURL: srv.URL, Model: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Generate(context.Background(), PromptPayload{Prompt: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	if h := got.Header.Get("Authorization"); h != "" {
+		t.Errorf("Authorization = %q, want none without an API key", h)
+	}
+	for _, k := range []string{"temperature", "max_tokens"} {
+		if _, ok := body[k]; ok {
+			t.Errorf("request sets %s = %v, want it left to the server", k, body[k])
+		}
+	}
+	if p.Name() != "openai" {
+		t.Errorf("name = %q, want openai", p.Name())
+	}
+}
+
+func TestOpenAICompatibleProviderErrors(t *testing.T) {
+	if _, err := NewOpenAICompatibleProvider("local", config.OpenAIConfig{Model: "m"}); err == nil {
+		t.Error("missing base URL was accepted")
+	}
+	if _, err := NewOpenAICompatibleProvider("local", config.OpenAIConfig{BaseURL: "http://x"}); err == nil {
+		t.Error("missing model was accepted")
+	}
+
+	srv := chatServer(t, func(*http.Request, map[string]any) (int, string) {
+		return http.StatusUnauthorized, "bad key"
+	})
+	p, err := NewOpenAICompatibleProvider("local", config.OpenAIConfig{BaseURL: srv.URL, Model: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = p.Generate(context.Background(), PromptPayload{Prompt: "hello"})
+	if err == nil || !strings.Contains(err.Error(), "local: http 401: bad key") {
+		t.Errorf("err = %v, want the HTTP status and body", err)
+	}
+}
+
+func TestOpenAICompatibleProviderStructuredOutput(t *testing.T) {
+	var format map[string]any
+	srv := chatServer(t, func(_ *http.Request, b map[string]any) (int, string) {
+		format, _ = b["response_format"].(map[string]any)
+		return http.StatusOK, chatReply("{}")
+	})
+	prompt := PromptPayload{Prompt: "hello", JSON: true, Schema: ProjectIdeaSchema}
+
+	p, err := NewOpenAICompatibleProvider("local", config.OpenAIConfig{BaseURL: srv.URL, Model: "m", StructuredOutput: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Generate(context.Background(), prompt); err != nil {
+		t.Fatal(err)
+	}
+	schema, _ := format["json_schema"].(map[string]any)
+	if format["type"] != "json_schema" || schema["name"] != ProjectIdeaSchema.Name || schema["strict"] != true {
+		t.Fatalf("response_format = %v, want a strict json_schema named %q", format, ProjectIdeaSchema.Name)
+	}
+	if root, _ := schema["schema"].(map[string]any); root["type"] != "object" {
+		t.Errorf("schema root = %v, want the project idea object", schema["schema"])
+	}
+
+	p, err = NewOpenAICompatibleProvider("local", config.OpenAIConfig{BaseURL: srv.URL, Model: "m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Generate(context.Background(), prompt); err != nil {
+		t.Fatal(err)
+	}
+	if format != nil {
+		t.Errorf("response_format = %v, want none with structured output off", format)
+	}
+}
+
+func TestOpenAICompatibleProviderFallsBackWhenSchemaRejected(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnprocessableEntity} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var withSchema []bool
+			srv := chatServer(t, func(_ *http.Request, b map[string]any) (int, string) {
+				_, ok := b["response_format"]
+				withSchema = append(withSchema, ok)
+				if ok {
+					return status, "response_format is not supported"
+				}
+				return http.StatusOK, chatReply("{}")
+			})
+			p, err := NewOpenAICompatibleProvider("local", config.OpenAIConfig{BaseURL: srv.URL, Model: "m", StructuredOutput: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			prompt := PromptPayload{Prompt: "hello", JSON: true, Schema: ProjectIdeaSchema}
+			for i := 0; i < 2; i++ {
+				if _, err := p.Generate(context.Background(), prompt); err != nil {
+					t.Fatalf("generate %d: %v", i+1, err)
+				}
+			}
+			// The second prompt skips the schema the server already refused.
+			if want := []bool{true, false, false}; !reflect.DeepEqual(withSchema, want) {
+				t.Errorf("requests with response_format = %v, want %v", withSchema, want)
+			}
+			if !p.schemaRejected.Load() {
+				t.Error("schemaRejected was not set")
+			}
+		})
+	}
+}
+
+func TestOpenAICompatibleProviderKeepsSchemaOnServerError(t *testing.T) {
+	calls := 0
+	srv := chatServer(t, func(*http.Request, map[string]any) (int, string) {
+		calls++
+		return http.StatusInternalServerError, "overloaded"
+	})
+	p, err := NewOpenAICompatibleProvider("local", config.OpenAIConfig{BaseURL: srv.URL, Model: "m", StructuredOutput: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = p.Generate(context.Background(), PromptPayload{Prompt: "hello", JSON: true, Schema: ProjectIdeaSchema})
+	if err == nil || !strings.Contains(err.Error(), "http 500") {
+		t.Errorf("err = %v, want the HTTP 500", err)
+	}
+	if calls != 1 || p.schemaRejected.Load() {
+		t.Errorf("calls = %d, schemaRejected = %v; want one request and the schema kept", calls, p.schemaRejected.Load())
+	}
+}
@@ -25,6 +25,8 @@ func BuildPivot(dominant string, ref domain.Project) Pivot {
 		pivot = "Shift the implementation approach and architecture while keeping within the allowed tech constraints."
 	case "target users":
 		pivot = "Change the target user segment to a different audience and adjust the value proposition accordingly."
+	case "problem":
+		pivot = "Address a different underlying problem; do not restate the reference problem in other words."
 	case "title":
 		pivot = "Change the project framing and title to a distinct concept and domain context."
 	default:
@@ -49,6 +51,59 @@ func BuildPivot(dominant string, ref domain.Project) Pivot {
 	return Pivot{Reason: reason, Prompt: prompt}
 }
 
+// maxAvoidRejected caps how many rejected attempts an avoid-list repeats back
+// to the model; older ones add prompt length without adding direction.
+const maxAvoidRejected = 5
+
+// AvoidList collects what a regeneration must steer away from: ideas rejected
+// earlier in the session and the saved projects closest to them.
+type AvoidList struct {
+	Rejected []ProjectIdea
+	Similar  []domain.Project
+}
+
+func (a AvoidList) Empty() bool {
+	return len(a.Rejected) == 0 && len(a.Similar) == 0
+}
+
+// prompt renders the avoid-list compactly: names, problems and must-have
+// features only.
+func (a AvoidList) prompt() string {
+	rejected := a.Rejected
+	if len(rejected) > maxAvoidRejected {
+		rejected = rejected[len(rejected)-maxAvoidRejected:]
+	}
+	var b strings.Builder
+	if len(rejected) > 0 {
+		b.WriteString("Previously rejected attempts (do NOT repeat their name, problem or core features):\n")
+		for _, idea := range rejected {
+			b.WriteString("- " + safeLine(idea.Project.Name) + " | problem: " + safeLine(truncateLine(idea.Project.Problem.Problem, 160)) + " | must_have: [" + safeCSV(firstN(idea.Project.MVP.MustHave, 4)) + "]\n")
+		}
+	}
+	if len(a.Similar) > 0 {
+		b.WriteString("Closest saved projects (the new idea must be clearly distinct from these too):\n")
+		for _, p := range a.Similar {
+			b.WriteString("- " + safeLine(p.Title) + " | problem: " + safeLine(truncateLine(p.ProblemStatement, 160)) + " | core_features: [" + safeCSV(firstN(p.CoreFeatures, 4)) + "]\n")
+		}
+	}
+	return b.String()
+}
+
+func truncateLine(s string, max int) string {
+	s = strings.TrimSpace(s)
+	if r := []rune(s); len(r) > max {
+		return strings.TrimSpace(string(r[:max])) + "…"
+	}
+	return s
+}
+
+func firstN(items []string, n int) []string {
+	if len(items) > n {
+		return items[:n]
+	}
+	return items
+}
+
 func safeLine(s string) string {
 	s = strings.ReplaceAll(s, "\n", " ")
 	s = strings.ReplaceAll(s, "\r", " ")
@@ -0,0 +1,141 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"quibit/internal/config"
+	"quibit/internal/project"
+)
+
+// Provider slots are process-wide so every manager talking to the same
+// provider shares one limit, however many pool workers are running.
+var (
+	providerSlotsMu sync.Mutex
+	providerSlots   = map[string]chan struct{}{}
+)
+
+func acquireProviderSlot(ctx context.Context, name string, limit int) (func(), error) {
+	if limit <= 0 {
+		return func() {}, nil
+	}
+	providerSlotsMu.Lock()
+	slots, ok := providerSlots[name]
+	if !ok || cap(slots) != limit {
+		slots = make(chan struct{}, limit)
+		providerSlots[name] = slots
+	}
+	providerSlotsMu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return func() {}, ctx.Err()
+	}
+}
+
+type CandidateState int
+
+const (
+	CandidateQueued CandidateState = iota
+	CandidateRunning
+	CandidateAccepted
+	CandidateRejected
+)
+
+type CandidateEvent struct {
+	Index int
+	State CandidateState
+	Err   error
+}
+
+type CandidateOutcome struct {
+	Candidate ExploreCandidate
+	Err       error
+}
+
+// ExpandIdeaSpecsConcurrently expands specs on a bounded worker pool sharing
+// one provider chain. Each candidate must pass the quality gate; a chain-wide
+// provider failure or cancellation stops the remaining workers.
+func ExpandIdeaSpecsConcurrently(ctx context.Context, specs []project.IdeaSpec, onEvent func(CandidateEvent)) ([]CandidateOutcome, error) {
+	cfg := config.LoadAIConfig()
+	m, err := NewProviderChain(cfg)
+	if err != nil {
+		return nil, err
+	}
+	gate, err := newQualityGate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if onEvent == nil {
+		onEvent = func(CandidateEvent) {}
+	}
+
+	workers := cfg.Workers
+	if workers > len(specs) {
+		workers = len(specs)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outcomes := make([]CandidateOutcome, len(specs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var fatalOnce sync.Once
+	var fatal error
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				onEvent(CandidateEvent{Index: i, State: CandidateRunning})
+				cand, err := expandIdeaSpec(ctx, m, specs[i])
+				if err == nil {
+					var v qualityVerdict
+					if v, err = gate.evaluate(ctx, cand.Idea); err == nil {
+						cand.Meta.Quality = &v.scores
+						if !v.ok() {
+							err = fmt.Errorf("explore: %w: %s", ErrQualityGateFailed, v.summary())
+						}
+					}
+				}
+				outcomes[i] = CandidateOutcome{Candidate: cand, Err: err}
+				if err != nil {
+					if errors.Is(err, ErrProvidersFailed) || ctx.Err() != nil {
+						fatalOnce.Do(func() {
+							fatal = err
+							cancel()
+						})
+					}
+					onEvent(CandidateEvent{Index: i, State: CandidateRejected, Err: err})
+					continue
+				}
+				onEvent(CandidateEvent{Index: i, State: CandidateAccepted})
+			}
+		}()
+	}
+
+feed:
+	for i := range specs {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			for j := i; j < len(specs); j++ {
+				outcomes[j].Err = ctx.Err()
+			}
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if fatal != nil {
+		return outcomes, fatal
+	}
+	return outcomes, nil
+}
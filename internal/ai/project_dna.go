@@ -0,0 +1,39 @@
+package ai
+
+import (
+	"strings"
+
+	"quibit/internal/project"
+)
+
+// ExtractProjectDNA derives the technical identity of a generated idea. The
+// primary domain is the project kind when one was given, otherwise the first
+// primary user group.
+func ExtractProjectDNA(idea ProjectIdea, appType string, projectKind string) project.ProjectDNA {
+	p := idea.Project
+
+	domain := strings.TrimSpace(projectKind)
+	if domain == "" && len(p.TargetUsers.Primary) > 0 {
+		domain = p.TargetUsers.Primary[0]
+	}
+
+	stack := make([]string, 0, 4)
+	for _, v := range []string{p.TechStack.Backend, p.TechStack.Frontend, p.TechStack.Database, p.TechStack.Infra} {
+		if strings.TrimSpace(v) != "" {
+			stack = append(stack, v)
+		}
+	}
+
+	return project.ProjectDNA{
+		AppType:       appType,
+		PrimaryDomain: domain,
+		CoreTechStack: stack,
+		ArchitecturalStyle: project.InferArchitecturalStyle(
+			p.Description.DetailedExplanation,
+			p.TechStack.Justification,
+			p.TechStack.Backend,
+			p.TechStack.Infra,
+		),
+		ComplexityLevel: p.Complexity,
+	}.Canonical()
+}
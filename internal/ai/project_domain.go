@@ -0,0 +1,37 @@
+package ai
+
+import (
+	"strings"
+
+	"quibit/internal/domain"
+)
+
+// DomainProject flattens a generated idea into the shape the similarity scorer
+// and pivot builder compare.
+func DomainProject(idea ProjectIdea) domain.Project {
+	p := idea.Project
+
+	users := make([]string, 0, len(p.TargetUsers.Primary)+len(p.TargetUsers.Secondary))
+	users = append(users, p.TargetUsers.Primary...)
+	users = append(users, p.TargetUsers.Secondary...)
+
+	stack := make([]string, 0, 4)
+	for _, v := range []string{p.TechStack.Backend, p.TechStack.Frontend, p.TechStack.Database, p.TechStack.Infra} {
+		if v = strings.TrimSpace(v); v != "" {
+			stack = append(stack, v)
+		}
+	}
+
+	return domain.Project{
+		Title:               strings.TrimSpace(p.Name),
+		Summary:             strings.TrimSpace(p.Description.Summary),
+		ProblemStatement:    strings.TrimSpace(p.Problem.Problem),
+		TargetUsers:         users,
+		CoreFeatures:        p.MVP.MustHave,
+		MVPScope:            p.MVP.MustHave,
+		OptionalExtensions:  p.Future,
+		RecommendedStack:    strings.Join(stack, ", "),
+		EstimatedComplexity: strings.TrimSpace(p.Complexity),
+		EstimatedDuration:   strings.TrimSpace(p.Duration.Range),
+	}
+}
@@ -2,6 +2,7 @@ package ai
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	"quibit/internal/project"
@@ -12,6 +13,7 @@ type NextPhaseEvolutionPromptInput struct {
 	TechStack             []string
 	MVPScope              []string
 	ProjectDNA            project.ProjectDNA
+	PreviousPhases        []EvolutionPhase
 }
 
 const nextPhaseEvolutionPromptTemplate = "" +
@@ -22,6 +24,8 @@ const nextPhaseEvolutionPromptTemplate = "" +
 	"- overview: {{overview}}\n" +
 	"- tech_stack: {{tech_stack_json}}\n" +
 	"- mvp_scope: {{mvp_scope_json}}\n\n" +
+	"Accepted evolution history (oldest first; already part of the baseline above, build on the latest phase and do not repeat it):\n" +
+	"{{evolution_history}}\n\n" +
 	"Project DNA (treat as technical identity constraints):\n" +
 	"- app_type: {{dna_app_type}}\n" +
 	"- primary_domain: {{dna_primary_domain}}\n" +
@@ -65,6 +69,7 @@ func BuildNextPhaseEvolutionPrompt(in NextPhaseEvolutionPromptInput) string {
 		"{{overview}}":                safePromptValue(in.CurrentProjectOverview),
 		"{{tech_stack_json}}":         string(techStackJSON),
 		"{{mvp_scope_json}}":          string(mvpScopeJSON),
+		"{{evolution_history}}":       renderEvolutionHistory(in.PreviousPhases),
 		"{{dna_app_type}}":            safePromptValue(dna.AppType),
 		"{{dna_primary_domain}}":      safePromptValue(dna.PrimaryDomain),
 		"{{dna_core_tech_stack_csv}}": safePromptValue(strings.Join(dna.CoreTechStack, ", ")),
@@ -72,3 +77,24 @@ func BuildNextPhaseEvolutionPrompt(in NextPhaseEvolutionPromptInput) string {
 		"{{dna_complexity_level}}":    safePromptValue(dna.ComplexityLevel),
 	})
 }
+
+func renderEvolutionHistory(phases []EvolutionPhase) string {
+	if len(phases) == 0 {
+		return "- none (this is the first evolution after the MVP)"
+	}
+	lines := make([]string, 0, len(phases))
+	for i, p := range phases {
+		line := fmt.Sprintf("- phase %d: %s", i+1, safePromptValue(p.Goal))
+		if len(p.ArchitecturalChanges) > 0 {
+			line += " | architectural_changes: " + safePromptValue(strings.Join(p.ArchitecturalChanges, "; "))
+		}
+		if len(p.Added) > 0 {
+			line += " | added_scope: " + safePromptValue(strings.Join(p.Added, "; "))
+		}
+		if len(p.Removed) > 0 {
+			line += " | removed_scope: " + safePromptValue(strings.Join(p.Removed, "; "))
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -70,6 +70,7 @@ func normalizeGenerateProjectIdeaFromSections(raw string) GenerateProjectIdeaRes
 	if s, ok := sections["mvp_scope"]; ok {
 		mvp.Goal = pickMultilineValue(s, []string{"goal"})
 		mvp.MustHaveFeatures = pickList(s, []string{"must have", "must-have", "must have features", "core features", "features"})
+		mvp.NiceToHaveFeatures = pickList(s, []string{"nice to have", "nice-to-have", "nice to have features"})
 		mvp.OutOfScope = pickList(s, []string{"out of scope", "out-of-scope", "excluded"})
 	}
 
@@ -81,11 +82,17 @@ func normalizeGenerateProjectIdeaFromSections(raw string) GenerateProjectIdeaRes
 		}
 	}
 
+	focus := []string{}
+	if s, ok := sections["engineering_focus"]; ok {
+		focus = pickBareList(s)
+	}
+
 	out := GenerateProjectIdeaResponse{
 		Overview:         ov,
 		TechStack:        ts,
 		MVPScope:         mvp,
 		LearningOutcomes: learning,
+		EngineeringFocus: focus,
 	}
 	return sanitizeGenerateProjectIdeaResponse(out)
 }
@@ -127,9 +134,11 @@ func sanitizeGenerateProjectIdeaResponse(in GenerateProjectIdeaResponse) Generat
 
 	in.MVPScope.Goal = cleanText(in.MVPScope.Goal)
 	in.MVPScope.MustHaveFeatures = cleanList(in.MVPScope.MustHaveFeatures)
+	in.MVPScope.NiceToHaveFeatures = cleanList(in.MVPScope.NiceToHaveFeatures)
 	in.MVPScope.OutOfScope = cleanList(in.MVPScope.OutOfScope)
 
 	in.LearningOutcomes = cleanList(in.LearningOutcomes)
+	in.EngineeringFocus = cleanList(in.EngineeringFocus)
 
 	return in
 }
@@ -241,6 +250,7 @@ func canonicalHeading(line string) string {
 	l := strings.TrimSpace(line)
 	l = strings.Trim(l, "#*:- ")
 	l = strings.ToLower(l)
+	l = strings.TrimSpace(strings.TrimPrefix(l, "section:"))
 	l = strings.ReplaceAll(l, "_", " ")
 	l = strings.ReplaceAll(l, "-", " ")
 	for strings.Contains(l, "  ") {
@@ -256,6 +266,8 @@ func canonicalHeading(line string) string {
 		return "mvp_scope"
 	case "learning outcomes", "learning", "outcomes" :
 		return "learning_outcomes"
+	case "engineering focus areas", "engineering focus", "focus areas":
+		return "engineering_focus"
 	default:
 		return ""
 	}
@@ -398,6 +410,9 @@ func matchKey(k string, candidates []string) bool {
 }
 
 func normalizeKey(s string) string {
+	if i := strings.Index(s, "("); i > 0 {
+		s = s[:i]
+	}
 	s = strings.ToLower(strings.TrimSpace(s))
 	s = strings.ReplaceAll(s, "_", " ")
 	s = strings.ReplaceAll(s, "-", " ")
@@ -5,6 +5,7 @@ type GenerateProjectIdeaResponse struct {
 	TechStack         TechStack       `json:"recommended_tech_stack"`
 	MVPScope          MVPScope        `json:"mvp_scope"`
 	LearningOutcomes  []string        `json:"learning_outcomes"`
+	EngineeringFocus  []string        `json:"engineering_focus_areas"`
 }
 
 type ProjectOverview struct {
@@ -26,5 +27,6 @@ type TechStack struct {
 type MVPScope struct {
 	Goal            string   `json:"goal"`
 	MustHaveFeatures []string `json:"must_have_features"`
+	NiceToHaveFeatures []string `json:"nice_to_have_features"`
 	OutOfScope      []string `json:"out_of_scope"`
 }
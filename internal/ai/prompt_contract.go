@@ -272,9 +272,12 @@ func BuildProjectEvolutionPrompt(in EvolutionInput) string {
 		"}\n"
 }
 
-func BuildProjectIdeaPivotPrompt(in model.ProjectInput, reason RetryReason, strategy PivotStrategy) string {
+// BuildProjectIdeaPivotPrompt appends the regeneration instructions to the base
+// prompt. References name saved projects the new idea must move away from, and
+// avoid lists what was already rejected in the session.
+func BuildProjectIdeaPivotPrompt(in model.ProjectInput, reason RetryReason, strategy PivotStrategy, avoid AvoidList, refs ...Pivot) string {
 	base := BuildProjectIdeaPrompt(in)
-	return base + "\n" +
+	prompt := base + "\n" +
 		"Regeneration:\n" +
 		"- retry_reason: " + string(reason) + "\n" +
 		"- pivot_strategy: " + string(strategy) + "\n\n" +
@@ -284,6 +287,15 @@ func BuildProjectIdeaPivotPrompt(in model.ProjectInput, reason RetryReason, stra
 		"Rules:\n" +
 		"- You MUST follow the pivot strategy.\n" +
 		"- The new idea must be meaningfully different from the previous attempt.\n"
+	for _, ref := range refs {
+		prompt += "\n" +
+			"Dominant similarity: " + ref.Reason + "\n" +
+			ref.Prompt
+	}
+	if !avoid.Empty() {
+		prompt += "\n" + avoid.prompt()
+	}
+	return prompt
 }
 
 func pivotStrategyInstruction(strategy PivotStrategy) string {
@@ -4,6 +4,11 @@ import "context"
 
 type PromptPayload struct {
 	Prompt string
+	JSON   bool
+
+	// Schema, when set, asks providers with native structured output to
+	// constrain the answer to it. It implies JSON.
+	Schema *ResponseSchema
 }
 
 type AIResult struct {
@@ -15,6 +20,14 @@ type AIResult struct {
 	ProviderError string
 
 	LatencyMS int64
+
+	// JSONRepair is how the answer was turned into valid JSON; empty for
+	// answers that were not parsed as JSON.
+	JSONRepair JSONRepair
+
+	// Quality is set on generated ideas once they have been through the
+	// quality gate.
+	Quality *QualityScores
 }
 
 type AIProvider interface {
@@ -1,24 +1,39 @@
 package ai
 
 import (
+	"context"
 	"fmt"
-	"regexp"
 	"strings"
+	"sync"
+
+	"quibit/internal/config"
+	"quibit/internal/quality"
 )
 
 type qualityVerdict struct {
 	decision qualityDecision
 	hardFail bool
 	reasons  []string
+	scores   QualityScores
+}
+
+// QualityScores are the numeric results of the quality gate for one idea.
+// Heuristic is the ruleset score; Combined blends it with the judge's rubric
+// score when the judge ran, and equals Heuristic otherwise.
+type QualityScores struct {
+	Heuristic  float64
+	Judge      *QualityJudgement
+	JudgeError string
+	Combined   float64
 }
 
 type qualityDecision string
 
 const (
-	qualityAccept     qualityDecision = "ACCEPT"
-	qualityRefine     qualityDecision = "REFINE"
-	qualityPivot      qualityDecision = "PIVOT"
-	qualityRegenerate qualityDecision = "REGENERATE"
+	qualityAccept     qualityDecision = qualityDecision(quality.Accept)
+	qualityRefine     qualityDecision = qualityDecision(quality.Refine)
+	qualityPivot      qualityDecision = qualityDecision(quality.Pivot)
+	qualityRegenerate qualityDecision = qualityDecision(quality.Regenerate)
 )
 
 func (v qualityVerdict) ok() bool {
@@ -41,292 +56,123 @@ func (v qualityVerdict) summary() string {
 	return fmt.Sprintf("decision=%s; %s", v.decision, strings.Join(v.reasons, "; "))
 }
 
-func evaluateIdeaQuality(idea ProjectIdea) qualityVerdict {
-
-	allText := strings.ToLower(strings.TrimSpace(strings.Join([]string{
-		idea.Project.Name,
-		idea.Project.Tagline,
-		idea.Project.Description.Summary,
-		idea.Project.Description.DetailedExplanation,
-		idea.Project.Problem.Problem,
-		idea.Project.Problem.WhyItMatters,
-		idea.Project.Problem.CurrentSolutionsAndGaps,
-		strings.Join(idea.Project.ValueProp.KeyBenefits, " "),
-		idea.Project.ValueProp.WhyThisProjectIsInteresting,
-		idea.Project.ValueProp.PortfolioValue,
-		idea.Project.MVP.Goal,
-		strings.Join(idea.Project.MVP.MustHave, " "),
-		strings.Join(idea.Project.MVP.NiceToHave, " "),
-		strings.Join(idea.Project.MVP.OutOfScope, " "),
-		idea.Project.TechStack.Justification,
-		strings.Join(idea.Project.Future, " "),
-		strings.Join(idea.Project.Learning, " "),
-	}, " | ")))
-
-	interestingText := strings.ToLower(strings.TrimSpace(strings.Join([]string{
-		idea.Project.ValueProp.WhyThisProjectIsInteresting,
-		idea.Project.ValueProp.PortfolioValue,
-		idea.Project.Tagline,
-		idea.Project.Description.Summary,
-		idea.Project.TechStack.Justification,
-	}, " | ")))
-
-	if looksCliche(allText) && !hasExtremeTwist(allText) {
-		return qualityVerdict{
-			hardFail: true,
-			decision: qualityRegenerate,
-			reasons:  []string{"anti-generic FAIL: cliché category without an extreme technical twist"},
-		}
-	}
-	if looksLikeClone(allText) {
-		return qualityVerdict{
-			hardFail: true,
-			decision: qualityRegenerate,
-			reasons:  []string{"anti-generic FAIL: clone framing (\"X clone\" / \"like X\")"},
-		}
-	}
-	if looksLikeCRUD(allText) && !hasTechnicalDepthSignals(allText) && !hasConstraintSignals(allText) && !hasExtremeTwist(allText) {
-		return qualityVerdict{
-			hardFail: true,
-			decision: qualityRegenerate,
-			reasons:  []string{"anti-generic FAIL: CRUD-y scope with no depth/constraints/twist"},
-		}
-	}
-
-	technicalDepth := hasTechnicalDepthSignals(allText) || hasExtremeTwist(allText)
-	nonTrivialConstraint := hasConstraintSignals(allText) || hasExtremeTwist(allText)
-	tradeoffs := hasTradeoffSignals(allText)
-	if !technicalDepth {
-		return qualityVerdict{
-			hardFail: true,
-			decision: qualityRegenerate,
-			reasons:  []string{"technical depth FAIL: no concrete engineering depth signals (reads like a thin app idea)"},
-		}
-	}
-
-	diffOK := hasDifferentiationSignals(interestingText) || (hasExtremeTwist(allText) && hasTechnicalDepthSignals(allText))
-	if !diffOK {
-		return qualityVerdict{
-			decision: qualityPivot,
-			reasons:  []string{"differentiation FAIL: no clear unique core differentiator (would not stop a reviewer from scrolling)"},
-		}
-	}
-
-	scopeOK, scopeReason := scopeRealismCheck(idea)
-	if !scopeOK {
-		return qualityVerdict{
-			decision: qualityRefine,
-			reasons:  []string{"scope/realism FAIL: " + scopeReason},
-		}
-	}
-
-	interviewable := hasInterviewSignals(allText)
-	if !interviewable {
-		return qualityVerdict{
-			decision: qualityRefine,
-			reasons:  []string{"portfolio worthiness FAIL: not clearly interviewable (missing architecture/system-design cues)"},
-		}
-	}
-	if !nonTrivialConstraint || !tradeoffs {
-		missing := []string{}
-		if !nonTrivialConstraint {
-			missing = append(missing, "non-trivial constraint")
-		}
-		if !tradeoffs {
-			missing = append(missing, "explicit trade-off")
-		}
-		return qualityVerdict{
-			decision: qualityRefine,
-			reasons:  []string{"technical depth incomplete: missing " + strings.Join(missing, " + ")},
-		}
-	}
-
-	return qualityVerdict{decision: qualityAccept}
-}
-
-func looksCliche(text string) bool {
-	needles := []string{
-		"todo",
-		"to-do",
-		"habit tracker",
-		"weather app",
-		"url shortener",
-		"shorten url",
-		"blog platform",
-		"e-commerce",
-		"ecommerce",
-		"shopping cart",
-		"chat app",
-		"expense tracker",
-		"personal finance",
-		"pomodoro",
-		"notes app",
-		"note-taking",
-		"recipe app",
-		"movie tracker",
-	}
-	for _, n := range needles {
-		if strings.Contains(text, n) {
-			return true
-		}
-	}
-	return false
-}
-
-func hasExtremeTwist(text string) bool {
-	return containsAny(text,
-		"end-to-end encryption", "e2ee", "zero-knowledge",
-		"differential privacy", "privacy budget",
-		"crdt", "offline-first", "local-first", "conflict-free",
-		"federated", "matrix protocol", "activitypub",
-		"formal verification", "model checking",
-		"deterministic replay", "tamper-evident", "append-only log",
-		"real-time", "backpressure", "streaming",
-	)
-}
-
-func looksLikeCRUD(text string) bool {
-	if containsAny(text, "crud", "create read update delete", "create, read, update, delete") {
-		return true
-	}
-	crudish := containsAny(text,
-		"add/edit/delete", "add, edit, delete",
-		"manage users", "manage items", "admin panel", "admin dashboard",
-		"login", "sign in", "sign-up", "register", "authentication",
-		"dashboard", "profile page", "settings page",
-	)
-	return crudish && !hasTechnicalDepthSignals(text) && !hasConstraintSignals(text)
-}
-
-func looksLikeClone(text string) bool {
-	if containsAny(text, " clone", "like trello", "like notion", "like spotify", "like netflix", "like uber") {
-		return true
-	}
-	cloneRe := regexp.MustCompile(`\b(clone of|a clone of|like\s+(notion|trello|spotify|netflix|uber|airbnb|twitter|instagram))\b`)
-	return cloneRe.MatchString(text)
-}
-
-func hasTechnicalDepthSignals(text string) bool {
-	return containsAny(text,
-		"event-driven", "queue", "job queue", "streaming", "pub/sub",
-		"idempotency", "dedup", "outbox", "saga",
-		"rate limit", "backpressure",
-		"observability", "tracing", "opentelemetry", "slo",
-		"multi-tenant", "rbac", "abac", "audit log",
-		"encryption", "key management", "kms",
-		"indexing", "inverted index", "search ranking",
-		"caching", "cache invalidation",
-		"consistency", "distributed", "replication",
-		"crdt", "offline-first", "local-first",
-		"vector", "embedding", "retrieval", "rag",
-	)
-}
-
-func hasTradeoffSignals(text string) bool {
-	return containsAny(text,
-		"trade-off", "tradeoff", "vs.", " vs ",
-		"latency vs", "cost vs", "consistency vs", "availability vs",
-		"privacy vs", "accuracy vs", "throughput vs",
-		"choose", "we choose", "we decided",
-	)
-}
-
-func hasConstraintSignals(text string) bool {
-	return containsAny(text,
-		"performance", "latency", "throughput", "p99",
-		"privacy", "pii", "gdpr", "hipaa",
-		"reliability", "resilience", "fault", "retry", "circuit breaker",
-		"offline", "low bandwidth",
-		"security", "threat model", "abuse", "rate limiting",
-		"dx", "developer experience", "schema enforcement",
-	)
-}
-
-func hasScalabilitySignals(text string) bool {
-	return containsAny(text,
-		"multi-tenant", "shard", "sharding", "partition",
-		"horizontal scale", "autoscaling",
-		"plugin system", "extensions", "marketplace",
-		"team plan", "enterprise", "organization",
-	)
-}
-
-func hasInterviewSignals(text string) bool {
-	return containsAny(text,
-		"architecture", "system design", "data model",
-		"consistency", "availability", "idempotency",
-		"queue", "caching", "observability", "slo",
-	)
-}
-
-func hasDifferentiationSignals(text string) bool {
-	if hasExtremeTwist(text) {
-		return true
-	}
-	return containsAny(text,
-		"tamper-evident", "append-only log", "merkle",
-		"deterministic replay",
-		"threat model",
-		"policy engine", "rego", "opa",
-		"crdt", "local-first", "offline-first",
-		"zero-knowledge", "zk", "end-to-end encryption", "e2ee",
-		"differential privacy", "privacy budget",
-		"backpressure", "outbox", "saga", "idempotency",
-		"vector index", "inverted index",
-	)
-}
-
-func scopeRealismCheck(idea ProjectIdea) (bool, string) {
-	must := idea.Project.MVP.MustHave
-	nice := idea.Project.MVP.NiceToHave
-	out := idea.Project.MVP.OutOfScope
-
-	if len(must) >= 8 {
-		return false, "MVP must-have list is too large (>=8) for a solo MVP"
-	}
-
-	bigRockCount := 0
-	all := strings.ToLower(strings.Join(must, " | "))
-	bigRocks := []string{
-		"payments", "subscription", "billing",
-		"marketplace",
-		"recommendation", "ranking",
-		"real-time chat", "messaging",
-		"social feed",
-		"multi-tenant",
-		"admin dashboard", "admin panel",
-		"ml training", "train model",
-	}
-	for _, k := range bigRocks {
-		if strings.Contains(all, k) {
-			bigRockCount++
-		}
-	}
-	if bigRockCount >= 3 {
-		return false, "too many big-scope features packed into MVP (payments/chat/recommendations/multi-tenant/etc.)"
-	}
-
-	fluff := func(items []string) bool {
-		if len(items) == 0 {
-			return true
-		}
-		joined := strings.ToLower(strings.Join(items, " | "))
-		return containsAny(joined, "etc", "more features", "improvements", "enhancements", "tbd") && len(items) <= 2
-	}
-	if fluff(nice) || fluff(out) {
-		return false, "scope lists are too vague (nice-to-have/out-of-scope read like placeholders)"
-	}
-	return true, ""
-}
-
-func containsAny(text string, needles ...string) bool {
-	for _, n := range needles {
-		if n == "" {
-			continue
-		}
-		if strings.Contains(text, strings.ToLower(n)) {
-			return true
-		}
+// LoadQualityRules returns the quality gate ruleset from
+// config.QualityRulesPath, or the embedded default. It is read once per run.
+var LoadQualityRules = sync.OnceValues(func() (*quality.Ruleset, error) {
+	return quality.Load(config.QualityRulesPath())
+})
+
+func evaluateIdeaQuality(rules *quality.Ruleset, idea ProjectIdea) qualityVerdict {
+	r := rules.Evaluate(QualityDocument(idea))
+	return qualityVerdict{
+		decision: qualityDecision(r.Decision),
+		hardFail: r.HardFail,
+		reasons:  r.Reasons,
+		scores:   QualityScores{Heuristic: r.Score, Combined: r.Score},
+	}
+}
+
+// qualityGate is the heuristic ruleset plus, when QUIBIT_JUDGE is on, an LLM
+// reviewer scoring the idea on a rubric.
+type qualityGate struct {
+	rules    *quality.Ruleset
+	judge    *ProviderManager
+	weight   float64
+	minScore float64
+}
+
+func newQualityGate(cfg config.AIConfig) (*qualityGate, error) {
+	rules, err := LoadQualityRules()
+	if err != nil {
+		return nil, err
+	}
+	g := &qualityGate{rules: rules, weight: cfg.JudgeWeight, minScore: cfg.JudgeMinScore}
+	if !cfg.Judge {
+		return g, nil
+	}
+	judgeCfg := cfg
+	if len(cfg.JudgeProviders) > 0 {
+		judgeCfg.Providers = cfg.JudgeProviders
+	}
+	if g.judge, err = NewProviderChain(judgeCfg); err != nil {
+		return nil, fmt.Errorf("quality judge: %w", err)
+	}
+	return g, nil
+}
+
+// evaluate runs the ruleset, then the judge unless the ruleset already hard
+// failed. An accepted idea is sent back when the combined score is below
+// QUIBIT_JUDGE_MIN_SCORE, with the decision taken from the judge's weakest
+// dimension. The judge is advisory: apart from cancellation, its failures are
+// recorded on the scores and the heuristic verdict stands.
+func (g *qualityGate) evaluate(ctx context.Context, idea ProjectIdea) (qualityVerdict, error) {
+	v := evaluateIdeaQuality(g.rules, idea)
+	if g.judge == nil || v.hardFail {
+		return v, nil
+	}
+	j, _, err := judgeProjectIdea(ctx, g.judge, idea)
+	if err != nil {
+		if ctx.Err() != nil {
+			return v, ctx.Err()
+		}
+		v.scores.JudgeError = sanitizeErr(err)
+		return v, nil
+	}
+	v.scores.Judge = &j
+	v.scores.Combined = (1-g.weight)*v.scores.Heuristic + g.weight*j.Score()
+	if v.decision == qualityAccept && v.scores.Combined < g.minScore {
+		dim, score := j.Weakest()
+		v.decision = judgeDecision(dim)
+		reason := fmt.Sprintf("judge FAIL: combined score %.2f below %.2f; weakest %s %.0f/10", v.scores.Combined, g.minScore, dim, score)
+		if j.Rationale != "" {
+			reason += " (" + j.Rationale + ")"
+		}
+		v.reasons = []string{reason}
+	}
+	return v, nil
+}
+
+// judgeDecision maps the weakest rubric dimension onto the regeneration that
+// addresses it: a weak differentiator needs a new angle, the rest a deeper or
+// tighter version of the same idea.
+func judgeDecision(dimension string) qualityDecision {
+	if dimension == JudgeDifferentiation {
+		return qualityPivot
+	}
+	return qualityRefine
+}
+
+// QualityDocument splits an idea into the texts quality signals match against.
+func QualityDocument(idea ProjectIdea) quality.Document {
+	return quality.Document{
+		All: strings.TrimSpace(strings.Join([]string{
+			idea.Project.Name,
+			idea.Project.Tagline,
+			idea.Project.Description.Summary,
+			idea.Project.Description.DetailedExplanation,
+			idea.Project.Problem.Problem,
+			idea.Project.Problem.WhyItMatters,
+			idea.Project.Problem.CurrentSolutionsAndGaps,
+			strings.Join(idea.Project.ValueProp.KeyBenefits, " "),
+			idea.Project.ValueProp.WhyThisProjectIsInteresting,
+			idea.Project.ValueProp.PortfolioValue,
+			idea.Project.MVP.Goal,
+			strings.Join(idea.Project.MVP.MustHave, " "),
+			strings.Join(idea.Project.MVP.NiceToHave, " "),
+			strings.Join(idea.Project.MVP.OutOfScope, " "),
+			idea.Project.TechStack.Justification,
+			strings.Join(idea.Project.Future, " "),
+			strings.Join(idea.Project.Learning, " "),
+		}, " | ")),
+		Interesting: strings.TrimSpace(strings.Join([]string{
+			idea.Project.ValueProp.WhyThisProjectIsInteresting,
+			idea.Project.ValueProp.PortfolioValue,
+			idea.Project.Tagline,
+			idea.Project.Description.Summary,
+			idea.Project.TechStack.Justification,
+		}, " | ")),
+		MustHave:   idea.Project.MVP.MustHave,
+		NiceToHave: idea.Project.MVP.NiceToHave,
+		OutOfScope: idea.Project.MVP.OutOfScope,
 	}
-	return false
 }
@@ -0,0 +1,100 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Judge rubric dimensions, in the order they are reported.
+const (
+	JudgeTechnicalDepth   = "technical_depth"
+	JudgeDifferentiation  = "differentiation"
+	JudgeScopeRealism     = "scope_realism"
+	JudgeInterviewability = "interviewability"
+)
+
+// QualityJudgement is the reviewer's rubric score for one idea, each
+// dimension from 1 to 10.
+type QualityJudgement struct {
+	TechnicalDepth   float64 `json:"technical_depth"`
+	Differentiation  float64 `json:"differentiation"`
+	ScopeRealism     float64 `json:"scope_realism"`
+	Interviewability float64 `json:"interviewability"`
+	Rationale        string  `json:"rationale"`
+}
+
+// Score is the rubric mean scaled to 0..1.
+func (j QualityJudgement) Score() float64 {
+	sum := j.TechnicalDepth + j.Differentiation + j.ScopeRealism + j.Interviewability
+	return sum / 40
+}
+
+// Weakest is the lowest-scoring dimension; ties go to the earlier one.
+func (j QualityJudgement) Weakest() (string, float64) {
+	name, score := JudgeTechnicalDepth, j.TechnicalDepth
+	for _, d := range []struct {
+		name  string
+		score float64
+	}{
+		{JudgeDifferentiation, j.Differentiation},
+		{JudgeScopeRealism, j.ScopeRealism},
+		{JudgeInterviewability, j.Interviewability},
+	} {
+		if d.score < score {
+			name, score = d.name, d.score
+		}
+	}
+	return name, score
+}
+
+func judgeProjectIdea(ctx context.Context, m *ProviderManager, idea ProjectIdea) (QualityJudgement, AIResult, error) {
+	prompt := BuildQualityJudgePrompt(idea)
+	const maxAttempts = 2
+	var lastErr error
+	var lastMeta AIResult
+	for i := 0; i < maxAttempts; i++ {
+		res, err := m.Generate(ctx, PromptPayload{Prompt: prompt, JSON: true, Schema: QualityJudgementSchema})
+		if err != nil {
+			return QualityJudgement{}, AIResult{}, err
+		}
+		raw := m.normalizeJSONAnswer(ctx, &res, QualityJudgementSchema)
+		lastMeta = res
+		j, err := decodeQualityJudgement(raw)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return j, res, nil
+	}
+	return QualityJudgement{}, lastMeta, lastErr
+}
+
+func decodeQualityJudgement(raw string) (QualityJudgement, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	var j QualityJudgement
+	if err := dec.Decode(&j); err != nil {
+		return QualityJudgement{}, fmt.Errorf("quality judge: invalid JSON: %w", err)
+	}
+	if err := dec.Decode(&struct{}{}); err == nil {
+		return QualityJudgement{}, fmt.Errorf("quality judge: invalid JSON: trailing content")
+	}
+	for _, d := range []struct {
+		name  string
+		score float64
+	}{
+		{JudgeTechnicalDepth, j.TechnicalDepth},
+		{JudgeDifferentiation, j.Differentiation},
+		{JudgeScopeRealism, j.ScopeRealism},
+		{JudgeInterviewability, j.Interviewability},
+	} {
+		if d.score < 1 || d.score > 10 {
+			return QualityJudgement{}, fmt.Errorf("quality judge: invalid JSON: %s must be between 1 and 10", d.name)
+		}
+	}
+	j.Rationale = strings.TrimSpace(j.Rationale)
+	return j, nil
+}
@@ -0,0 +1,39 @@
+package ai
+
+import "encoding/json"
+
+const qualityJudgePromptTemplate = "" +
+	"You are a Staff Engineer reviewing portfolio project ideas for a hiring panel. Be strict and skeptical.\n" +
+	"Return ONLY valid JSON. Do not include explanation, formatting, markdown, or extra text.\n" +
+	"You MUST return exactly one JSON object and nothing else.\n\n" +
+	"Candidate idea (JSON):\n" +
+	"{{idea_json}}\n\n" +
+	"Rubric (score each dimension as an integer from 1 to 10):\n" +
+	"- technical_depth: concrete, non-trivial engineering problems the builder must actually solve. Buzzwords without a mechanism score low.\n" +
+	"- differentiation: one sharp core differentiator that is central to the design, not a bolted-on feature or a clone with a twist.\n" +
+	"- scope_realism: the MVP is a minimal slice one developer can ship in the stated duration.\n" +
+	"- interviewability: the builder could defend architecture, data model and explicit trade-offs in a system design interview.\n\n" +
+	"Rules:\n" +
+	"- Judge what the idea commits to, not the vocabulary it uses. Keyword stuffing (\"trade-off\", \"idempotency\", \"CRDT\") without substance must lower the score.\n" +
+	"- 7 or above means a reviewer would stop scrolling; 4 or below means it should not be built as-is.\n" +
+	"- rationale is at most two sentences and names the weakest dimension.\n" +
+	"- Fill EVERY field in the schema.\n" +
+	"- Do NOT add, remove, or rename any fields.\n\n" +
+	"Schema (must include ALL fields):\n" +
+	"{\n" +
+	"  \"technical_depth\": number,\n" +
+	"  \"differentiation\": number,\n" +
+	"  \"scope_realism\": number,\n" +
+	"  \"interviewability\": number,\n" +
+	"  \"rationale\": string\n" +
+	"}\n"
+
+func BuildQualityJudgePrompt(idea ProjectIdea) string {
+	ideaJSON, err := json.MarshalIndent(idea, "", "  ")
+	if err != nil {
+		ideaJSON = []byte("{}")
+	}
+	return renderTemplate(qualityJudgePromptTemplate, map[string]string{
+		"{{idea_json}}": string(ideaJSON),
+	})
+}
@@ -0,0 +1,123 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"quibit/internal/config"
+)
+
+type ProviderFactory func(cfg config.AIConfig) (AIProvider, error)
+
+type Diagnosis struct {
+	Summary string
+	Action  string
+}
+
+type DiagnoseFunc func(err error) Diagnosis
+
+type providerRegistration struct {
+	factory  ProviderFactory
+	diagnose DiagnoseFunc
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]providerRegistration{}
+)
+
+func RegisterProvider(name string, factory ProviderFactory, diagnose DiagnoseFunc) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		panic("ai: RegisterProvider with empty name")
+	}
+	if factory == nil {
+		panic("ai: RegisterProvider with nil factory for " + name)
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[name]; exists {
+		panic("ai: provider registered twice: " + name)
+	}
+	registry[name] = providerRegistration{factory: factory, diagnose: diagnose}
+}
+
+func RegisteredProviders() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func NewProvider(name string, cfg config.AIConfig) (AIProvider, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	registryMu.RLock()
+	reg, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("ai: unknown provider %q (registered: %s)", name, strings.Join(RegisteredProviders(), ", "))
+	}
+	return reg.factory(cfg)
+}
+
+func DiagnoseProviderError(name string, err error) Diagnosis {
+	registryMu.RLock()
+	reg, ok := registry[strings.ToLower(strings.TrimSpace(name))]
+	registryMu.RUnlock()
+	if ok && reg.diagnose != nil {
+		return reg.diagnose(err)
+	}
+	return genericDiagnosis(err)
+}
+
+func NewProviderChain(cfg config.AIConfig) (*ProviderManager, error) {
+	if len(cfg.Providers) == 0 {
+		return nil, fmt.Errorf("ai: no providers configured (set QUIBIT_PROVIDERS)")
+	}
+	providers := make([]AIProvider, 0, len(cfg.Providers))
+	for _, name := range cfg.Providers {
+		p, err := NewProvider(name, cfg)
+		if err != nil {
+			registryMu.RLock()
+			_, known := registry[strings.ToLower(strings.TrimSpace(name))]
+			registryMu.RUnlock()
+			if !known {
+				return nil, err
+			}
+			p = staticErrorProvider{name: name, err: err}
+		}
+		providers = append(providers, p)
+	}
+	m, err := NewProviderManager(providers...)
+	if err != nil {
+		return nil, err
+	}
+	m.modelRepair = cfg.JSONRepair != config.JSONRepairLocal
+	m.limits = make(map[string]int, len(providers))
+	for _, p := range providers {
+		m.limits[p.Name()] = cfg.ConcurrencyFor(p.Name())
+	}
+	return m, nil
+}
+
+func genericDiagnosis(err error) Diagnosis {
+	if err == nil {
+		return Diagnosis{Summary: "unknown", Action: "Retry generation."}
+	}
+	if isRateLimitedError(err) {
+		return Diagnosis{
+			Summary: "Provider rejected the request due to rate limit / quota exhaustion.",
+			Action:  "Wait briefly and retry, or move another provider earlier in QUIBIT_PROVIDERS.",
+		}
+	}
+	return Diagnosis{
+		Summary: "Provider failed.",
+		Action:  "Retry. If it persists, check the provider credentials and network connectivity.",
+	}
+}
@@ -0,0 +1,166 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"quibit/internal/config"
+)
+
+const (
+	replayModeReplay = "replay"
+	replayModeRecord = "record"
+)
+
+func init() {
+	RegisterProvider("replay", newReplayFromConfig, diagnoseReplay)
+}
+
+func newReplayFromConfig(cfg config.AIConfig) (AIProvider, error) {
+	dir := strings.TrimSpace(cfg.ReplayDir)
+	if dir == "" {
+		return nil, fmt.Errorf("QUIBIT_REPLAY_DIR is required")
+	}
+	switch cfg.ReplayMode {
+	case "", replayModeReplay:
+		return NewReplayProvider(dir)
+	case replayModeRecord:
+		source := strings.TrimSpace(cfg.ReplaySource)
+		if source == "" {
+			return nil, fmt.Errorf("replay: QUIBIT_REPLAY_SOURCE is required in record mode")
+		}
+		if source == "replay" {
+			return nil, fmt.Errorf("replay: QUIBIT_REPLAY_SOURCE cannot be replay")
+		}
+		inner, err := NewProvider(source, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("replay: %w", err)
+		}
+		return NewRecordingProvider(inner, dir)
+	default:
+		return nil, fmt.Errorf("replay: QUIBIT_REPLAY_MODE must be replay|record")
+	}
+}
+
+func PromptFixtureKey(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+type ReplayProvider struct {
+	dir string
+}
+
+func NewReplayProvider(dir string) (*ReplayProvider, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil, fmt.Errorf("replay: fixtures dir is required")
+	}
+	return &ReplayProvider{dir: dir}, nil
+}
+
+func (p *ReplayProvider) Name() string { return "replay" }
+
+func (p *ReplayProvider) Generate(ctx context.Context, prompt PromptPayload) (AIResult, error) {
+	if ctx == nil {
+		return AIResult{}, fmt.Errorf("replay: ctx is nil")
+	}
+	if p == nil {
+		return AIResult{}, fmt.Errorf("replay: not initialized")
+	}
+	if err := ctx.Err(); err != nil {
+		return AIResult{}, err
+	}
+
+	key := PromptFixtureKey(prompt.Prompt)
+	b, err := os.ReadFile(filepath.Join(p.dir, key+".txt"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return AIResult{}, fmt.Errorf("replay: no fixture %s.txt in %s", key, p.dir)
+		}
+		return AIResult{}, fmt.Errorf("replay: read fixture: %w", err)
+	}
+	text := strings.TrimSpace(string(b))
+	if text == "" {
+		return AIResult{}, fmt.Errorf("replay: fixture %s.txt is empty", key)
+	}
+	return AIResult{Text: text, ProviderUsed: p.Name()}, nil
+}
+
+type RecordingProvider struct {
+	inner AIProvider
+	dir   string
+}
+
+func NewRecordingProvider(inner AIProvider, dir string) (*RecordingProvider, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("replay: inner provider is nil")
+	}
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil, fmt.Errorf("replay: fixtures dir is required")
+	}
+	return &RecordingProvider{inner: inner, dir: dir}, nil
+}
+
+func (p *RecordingProvider) Name() string { return p.inner.Name() }
+
+func (p *RecordingProvider) Generate(ctx context.Context, prompt PromptPayload) (AIResult, error) {
+	if p == nil || p.inner == nil {
+		return AIResult{}, fmt.Errorf("replay: not initialized")
+	}
+	start := time.Now()
+	res, err := p.inner.Generate(ctx, prompt)
+	if err != nil {
+		return AIResult{}, err
+	}
+	if err := p.write(prompt.Prompt, res.Text); err != nil {
+		return AIResult{}, err
+	}
+	if res.LatencyMS == 0 {
+		res.LatencyMS = time.Since(start).Milliseconds()
+	}
+	return res, nil
+}
+
+func (p *RecordingProvider) write(prompt string, text string) error {
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return fmt.Errorf("replay: create fixtures dir: %w", err)
+	}
+	key := PromptFixtureKey(prompt)
+	if err := os.WriteFile(filepath.Join(p.dir, key+".prompt"), []byte(prompt), 0o644); err != nil {
+		return fmt.Errorf("replay: write prompt: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(p.dir, key+".txt"), []byte(text), 0o644); err != nil {
+		return fmt.Errorf("replay: write fixture: %w", err)
+	}
+	return nil
+}
+
+func diagnoseReplay(err error) Diagnosis {
+	if err == nil {
+		return genericDiagnosis(err)
+	}
+	s := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(s, "no fixture"):
+		return Diagnosis{
+			Summary: "Replay fixtures do not contain a response for this prompt (the prompt or its inputs changed).",
+			Action:  "Re-record with QUIBIT_REPLAY_MODE=record and QUIBIT_REPLAY_SOURCE=<provider>, or add the missing <hash>.txt file.",
+		}
+	case strings.Contains(s, "quibit_replay"):
+		return Diagnosis{
+			Summary: "Replay provider is misconfigured.",
+			Action:  "Set QUIBIT_REPLAY_DIR (and QUIBIT_REPLAY_MODE/QUIBIT_REPLAY_SOURCE for recording), then retry.",
+		}
+	default:
+		return genericDiagnosis(err)
+	}
+}
@@ -0,0 +1,71 @@
+package ai
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type stubProvider struct {
+	text  string
+	calls int
+}
+
+func (p *stubProvider) Name() string { return "stub" }
+
+func (p *stubProvider) Generate(ctx context.Context, prompt PromptPayload) (AIResult, error) {
+	p.calls++
+	return AIResult{Text: p.text, ProviderUsed: p.Name()}, nil
+}
+
+func TestRecordingProviderWritesReplayableFixture(t *testing.T) {
+	dir := t.TempDir()
+	inner := &stubProvider{text: `{"project": {}}`}
+	rec, err := NewRecordingProvider(inner, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const prompt = "Generate one idea."
+	res, err := rec.Generate(context.Background(), PromptPayload{Prompt: prompt})
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if res.Text != inner.text || inner.calls != 1 {
+		t.Fatalf("record returned %q after %d calls, want the inner answer once", res.Text, inner.calls)
+	}
+
+	saved, err := os.ReadFile(filepath.Join(dir, PromptFixtureKey(prompt)+".prompt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(saved) != prompt {
+		t.Errorf("recorded prompt = %q, want %q", saved, prompt)
+	}
+
+	replay, err := NewReplayProvider(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := replay.Generate(context.Background(), PromptPayload{Prompt: prompt})
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if got.Text != inner.text || got.ProviderUsed != "replay" {
+		t.Errorf("replay = %q from %q, want the recorded answer from replay", got.Text, got.ProviderUsed)
+	}
+	if _, err := replay.Generate(context.Background(), PromptPayload{Prompt: "another prompt"}); err == nil {
+		t.Error("replay answered a prompt that was never recorded")
+	}
+}
+
+func TestReplayRecordModeRequiresSource(t *testing.T) {
+	t.Setenv("QUIBIT_PROVIDERS", "replay")
+	t.Setenv("QUIBIT_REPLAY_DIR", t.TempDir())
+	t.Setenv("QUIBIT_REPLAY_MODE", "record")
+	t.Setenv("QUIBIT_REPLAY_SOURCE", "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	if _, _, _, err := GenerateProjectIdeaOnceWithMeta(context.Background(), testInput); err == nil {
+		t.Fatal("record mode without QUIBIT_REPLAY_SOURCE did not fail")
+	}
+}
@@ -0,0 +1,105 @@
+package ai
+
+import (
+	"reflect"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// ResponseSchema is the JSON shape a prompt expects back. Providers that
+// support structured output send it natively; the others ignore it and rely on
+// the prose contract in the prompt.
+type ResponseSchema struct {
+	Name string
+	Root *SchemaNode
+}
+
+// SchemaNode is the subset of JSON Schema both Gemini and OpenAI strict mode
+// accept: objects with every property required, arrays, and scalars.
+type SchemaNode struct {
+	Type                 string                 `json:"type"`
+	Properties           map[string]*SchemaNode `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *SchemaNode            `json:"items,omitempty"`
+}
+
+var (
+	ProjectIdeaSchema        = SchemaOf[ProjectIdea]("project_idea")
+	ProjectEvolutionSchema   = SchemaOf[ProjectEvolution]("project_evolution")
+	NextPhaseEvolutionSchema = SchemaOf[NextPhaseEvolution]("next_phase_evolution")
+	EvolutionReadinessSchema = SchemaOf[EvolutionReadiness]("evolution_readiness")
+	QualityJudgementSchema   = SchemaOf[QualityJudgement]("quality_judgement")
+)
+
+// SchemaOf derives a schema from T's json tags, so the schema cannot drift
+// from the struct the answer is decoded into.
+func SchemaOf[T any](name string) *ResponseSchema {
+	return &ResponseSchema{Name: name, Root: schemaNodeOf(reflect.TypeFor[T]())}
+}
+
+func schemaNodeOf(t reflect.Type) *SchemaNode {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		closed := false
+		n := &SchemaNode{Type: "object", Properties: map[string]*SchemaNode{}, AdditionalProperties: &closed}
+		for i := range t.NumField() {
+			f := t.Field(i)
+			name := jsonFieldName(f)
+			if name == "" {
+				continue
+			}
+			n.Properties[name] = schemaNodeOf(f.Type)
+			n.Required = append(n.Required, name)
+		}
+		return n
+	case reflect.Slice, reflect.Array:
+		return &SchemaNode{Type: "array", Items: schemaNodeOf(t.Elem())}
+	case reflect.Bool:
+		return &SchemaNode{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &SchemaNode{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &SchemaNode{Type: "number"}
+	default:
+		return &SchemaNode{Type: "string"}
+	}
+}
+
+func jsonFieldName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch tag {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	default:
+		return tag
+	}
+}
+
+// genaiSchema converts n for Gemini's ResponseSchema. PropertyOrdering keeps
+// the struct's field order; Gemini otherwise emits keys alphabetically.
+func (n *SchemaNode) genaiSchema() *genai.Schema {
+	if n == nil {
+		return nil
+	}
+	s := &genai.Schema{Type: genai.Type(strings.ToUpper(n.Type)), Items: n.Items.genaiSchema()}
+	if len(n.Properties) > 0 {
+		s.Properties = make(map[string]*genai.Schema, len(n.Properties))
+		for k, v := range n.Properties {
+			s.Properties[k] = v.genaiSchema()
+		}
+		s.Required = n.Required
+		s.PropertyOrdering = n.Required
+	}
+	return s
+}
@@ -1,14 +1,177 @@
 package config
 
+import (
+	"strconv"
+	"strings"
+)
+
+var DefaultAIProviders = []string{"gemini", "huggingface"}
+
+const (
+	DefaultAIWorkers           = 4
+	DefaultProviderConcurrency = 2
+
+	// JSONRepairLocal limits JSON repair to the local fixer; the default also
+	// asks the model to fix what the fixer cannot.
+	JSONRepairLocal = "local"
+
+	DefaultJudgeWeight   = 0.5
+	DefaultJudgeMinScore = 0.75
+)
+
 type AIConfig struct {
-	GeminiAPIKey string
-	HFToken      string
+	Providers        []string
+	GeminiAPIKey     string
+	HFToken          string
+	HFModel          string
+	OpenAIConfigPath string
+	OllamaHost       string
+	OllamaModel      string
+	OllamaEndpoint   string
+	OllamaStream     bool
+	ReplayDir        string
+	ReplayMode       string
+	ReplaySource     string
+	Embedder         string
+	EmbeddingModel   string
+
+	// StructuredOutput lets providers that support it constrain JSON answers
+	// to the prompt's schema natively (QUIBIT_STRUCTURED_OUTPUT=0 disables).
+	StructuredOutput bool
+
+	// JSONRepair is "local" to skip the model repair call for broken JSON.
+	JSONRepair string
+
+	// Judge enables the LLM reviewer pass of the quality gate. JudgeProviders
+	// is its own chain (empty = Providers); JudgeWeight is the judge's share
+	// of the combined score and JudgeMinScore the combined score an idea
+	// needs to pass.
+	Judge          bool
+	JudgeProviders []string
+	JudgeWeight    float64
+	JudgeMinScore  float64
+
+	Workers             int
+	ProviderConcurrency map[string]int
+}
+
+// ConcurrencyFor is the max number of in-flight requests for one provider.
+func (c AIConfig) ConcurrencyFor(provider string) int {
+	if n, ok := c.ProviderConcurrency[strings.ToLower(strings.TrimSpace(provider))]; ok && n > 0 {
+		return n
+	}
+	if n, ok := c.ProviderConcurrency["*"]; ok && n > 0 {
+		return n
+	}
+	return DefaultProviderConcurrency
 }
 
 func LoadAIConfig() AIConfig {
 	_ = LoadDotEnv(".env")
 	return AIConfig{
-		GeminiAPIKey: GetenvOptional("GEMINI_API_KEY"),
-		HFToken:      GetenvOptional("HF_TOKEN"),
+		Providers:        parseProviderList(GetenvOptional("QUIBIT_PROVIDERS")),
+		GeminiAPIKey:     GetenvOptional("GEMINI_API_KEY"),
+		HFToken:          GetenvOptional("HF_TOKEN"),
+		HFModel:          GetenvOptional("HF_MODEL"),
+		OpenAIConfigPath: GetenvOptional("QUIBIT_OPENAI_CONFIG"),
+		OllamaHost:       GetenvOptional("OLLAMA_HOST"),
+		OllamaModel:      GetenvOptional("OLLAMA_MODEL"),
+		OllamaEndpoint:   strings.ToLower(GetenvOptional("OLLAMA_ENDPOINT")),
+		OllamaStream:     !envFalse("OLLAMA_STREAM"),
+		ReplayDir:        GetenvOptional("QUIBIT_REPLAY_DIR"),
+		ReplayMode:       strings.ToLower(GetenvOptional("QUIBIT_REPLAY_MODE")),
+		ReplaySource:     strings.ToLower(GetenvOptional("QUIBIT_REPLAY_SOURCE")),
+		Embedder:         strings.ToLower(GetenvOptional("QUIBIT_EMBEDDER")),
+		EmbeddingModel:   GetenvOptional("QUIBIT_EMBEDDING_MODEL"),
+		StructuredOutput: !envFalse("QUIBIT_STRUCTURED_OUTPUT"),
+		JSONRepair:       strings.ToLower(GetenvOptional("QUIBIT_JSON_REPAIR")),
+
+		Judge:          envTrue("QUIBIT_JUDGE") || GetenvOptional("QUIBIT_JUDGE_PROVIDERS") != "",
+		JudgeProviders: parseNameList(GetenvOptional("QUIBIT_JUDGE_PROVIDERS")),
+		JudgeWeight:    envUnitFloat("QUIBIT_JUDGE_WEIGHT", DefaultJudgeWeight),
+		JudgeMinScore:  envUnitFloat("QUIBIT_JUDGE_MIN_SCORE", DefaultJudgeMinScore),
+
+		Workers:             envPositiveInt("QUIBIT_WORKERS", DefaultAIWorkers),
+		ProviderConcurrency: parseConcurrencyList(GetenvOptional("QUIBIT_PROVIDER_CONCURRENCY")),
+	}
+}
+
+func parseProviderList(raw string) []string {
+	out := parseNameList(raw)
+	if len(out) == 0 {
+		return append([]string(nil), DefaultAIProviders...)
+	}
+	return out
+}
+
+func parseNameList(raw string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, name)
+	}
+	return out
+}
+
+func envTrue(key string) bool {
+	switch strings.ToLower(GetenvOptional(key)) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+func envFalse(key string) bool {
+	switch strings.ToLower(GetenvOptional(key)) {
+	case "0", "false", "no", "off":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseConcurrencyList reads "gemini=2,ollama=1"; a bare number or "*=N"
+// applies to every provider without its own entry.
+func parseConcurrencyList(raw string) map[string]int {
+	out := map[string]int{}
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			name, value = "*", item
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n <= 0 {
+			continue
+		}
+		out[strings.ToLower(strings.TrimSpace(name))] = n
+	}
+	return out
+}
+
+func envPositiveInt(key string, def int) int {
+	n, err := strconv.Atoi(GetenvOptional(key))
+	if err != nil || n <= 0 {
+		return def
+	}
+	return n
+}
+
+// envUnitFloat reads a value in [0, 1], falling back to def when unset or out
+// of range.
+func envUnitFloat(key string, def float64) float64 {
+	f, err := strconv.ParseFloat(GetenvOptional(key), 64)
+	if err != nil || f < 0 || f > 1 {
+		return def
 	}
+	return f
 }
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+type OpenAIConfig struct {
+	BaseURL        string            `json:"base_url"`
+	APIKey         string            `json:"api_key"`
+	Model          string            `json:"model"`
+	Headers        map[string]string `json:"headers"`
+	Temperature    *float64          `json:"temperature"`
+	MaxTokens      int               `json:"max_tokens"`
+	TimeoutSeconds int               `json:"timeout_seconds"`
+
+	// StructuredOutput sends response_format json_schema for prompts that
+	// carry a schema. It comes from AIConfig, not the config file.
+	StructuredOutput bool `json:"-"`
+}
+
+func LoadOpenAIConfig(path string) (OpenAIConfig, error) {
+	var cfg OpenAIConfig
+	path = strings.TrimSpace(path)
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return OpenAIConfig{}, fmt.Errorf("openai config: read %s: %w", path, err)
+		}
+		dec := json.NewDecoder(strings.NewReader(string(b)))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&cfg); err != nil {
+			return OpenAIConfig{}, fmt.Errorf("openai config: parse %s: %w", path, err)
+		}
+	}
+
+	if v := GetenvOptional("OPENAI_BASE_URL"); v != "" {
+		cfg.BaseURL = v
+	}
+	if v := GetenvOptional("OPENAI_API_KEY"); v != "" {
+		cfg.APIKey = v
+	}
+	if v := GetenvOptional("OPENAI_MODEL"); v != "" {
+		cfg.Model = v
+	}
+	if v := GetenvOptional("OPENAI_HEADERS"); v != "" {
+		headers, err := parseHeaderList(v)
+		if err != nil {
+			return OpenAIConfig{}, fmt.Errorf("openai config: OPENAI_HEADERS: %w", err)
+		}
+		if cfg.Headers == nil {
+			cfg.Headers = map[string]string{}
+		}
+		for k, val := range headers {
+			cfg.Headers[k] = val
+		}
+	}
+	if v := GetenvOptional("OPENAI_TEMPERATURE"); v != "" {
+		t, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return OpenAIConfig{}, fmt.Errorf("openai config: OPENAI_TEMPERATURE must be a number")
+		}
+		cfg.Temperature = &t
+	}
+	if v := GetenvOptional("OPENAI_MAX_TOKENS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return OpenAIConfig{}, fmt.Errorf("openai config: OPENAI_MAX_TOKENS must be a non-negative integer")
+		}
+		cfg.MaxTokens = n
+	}
+	if v := GetenvOptional("OPENAI_TIMEOUT_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return OpenAIConfig{}, fmt.Errorf("openai config: OPENAI_TIMEOUT_SECONDS must be a positive integer")
+		}
+		cfg.TimeoutSeconds = n
+	}
+
+	if strings.TrimSpace(cfg.BaseURL) == "" {
+		cfg.BaseURL = DefaultOpenAIBaseURL
+	}
+	return cfg, nil
+}
+
+// parseHeaderList accepts "Name: value" or "Name=value" pairs separated by commas or newlines.
+func parseHeaderList(raw string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, item := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == '\n' }) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		sep := strings.IndexAny(item, ":=")
+		if sep <= 0 {
+			return nil, fmt.Errorf("invalid header %q (want Name: value)", item)
+		}
+		name := strings.TrimSpace(item[:sep])
+		value := strings.TrimSpace(item[sep+1:])
+		if name == "" {
+			return nil, fmt.Errorf("invalid header %q (want Name: value)", item)
+		}
+		out[name] = value
+	}
+	return out, nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExportTemplateDir is where user overrides for export templates live:
+// QUIBIT_TEMPLATE_DIR, else $XDG_CONFIG_HOME/quibit/templates.
+func ExportTemplateDir() string {
+	if v := strings.TrimSpace(os.Getenv("QUIBIT_TEMPLATE_DIR")); v != "" {
+		return v
+	}
+	dir := quibitConfigDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "templates")
+}
+
+// QualityRulesPath is the user's quality gate ruleset: QUIBIT_QUALITY_RULES,
+// else the first of quality.yaml, quality.yml or quality.json in
+// $XDG_CONFIG_HOME/quibit. Empty means the embedded default.
+func QualityRulesPath() string {
+	if v := strings.TrimSpace(os.Getenv("QUIBIT_QUALITY_RULES")); v != "" {
+		return v
+	}
+	dir := quibitConfigDir()
+	if dir == "" {
+		return ""
+	}
+	for _, name := range []string{"quality.yaml", "quality.yml", "quality.json"} {
+		p := filepath.Join(dir, name)
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+func quibitConfigDir() string {
+	configHome := strings.TrimSpace(os.Getenv("XDG_CONFIG_HOME"))
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil || strings.TrimSpace(home) == "" {
+			return ""
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "quibit")
+}
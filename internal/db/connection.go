@@ -4,30 +4,84 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"quibit/internal/config"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+const sqliteScheme = "sqlite:"
+
 func Connect(ctx context.Context) (*gorm.DB, error) {
 	databaseURL := os.Getenv("DATABASE_URL")
 	if databaseURL == "" {
 		_ = config.LoadDotEnv(".env")
 		databaseURL = os.Getenv("DATABASE_URL")
 	}
-	if databaseURL == "" {
-		return nil, fmt.Errorf("DATABASE_URL is required (not found in environment or .env)")
-	}
+	databaseURL = strings.TrimSpace(databaseURL)
 
-	db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{
+	gormCfg := &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
-	})
+	}
+
+	if databaseURL == "" || strings.HasPrefix(strings.ToLower(databaseURL), sqliteScheme) {
+		path, err := sqlitePath(databaseURL)
+		if err != nil {
+			return nil, err
+		}
+		db, err := gorm.Open(sqlite.Open(sqliteDSN(path)), gormCfg)
+		if err != nil {
+			return nil, fmt.Errorf("connect to sqlite: %w", err)
+		}
+		return db.WithContext(ctx), nil
+	}
+
+	db, err := gorm.Open(postgres.Open(databaseURL), gormCfg)
 	if err != nil {
 		return nil, fmt.Errorf("connect to postgres: %w", err)
 	}
 
 	return db.WithContext(ctx), nil
 }
+
+// sqlitePath accepts sqlite:///abs/path.db, sqlite://rel/path.db or sqlite:path.db;
+// an empty URL or path falls back to $XDG_DATA_HOME/quibit/quibit.db.
+func sqlitePath(databaseURL string) (string, error) {
+	path := databaseURL
+	if len(path) >= len(sqliteScheme) {
+		path = path[len(sqliteScheme):]
+	}
+	path = strings.TrimPrefix(path, "//")
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	path = strings.TrimSpace(path)
+
+	if path == "" {
+		dataHome := strings.TrimSpace(os.Getenv("XDG_DATA_HOME"))
+		if dataHome == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("sqlite: resolve default database path: %w", err)
+			}
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+		path = filepath.Join(dataHome, "quibit", "quibit.db")
+	}
+
+	if path != ":memory:" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return "", fmt.Errorf("sqlite: create database dir: %w", err)
+		}
+	}
+	return path, nil
+}
+
+func sqliteDSN(path string) string {
+	return path + "?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)"
+}
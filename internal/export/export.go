@@ -0,0 +1,211 @@
+package export
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"quibit/internal/ai"
+)
+
+const (
+	FormatMarkdown = "md"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatHTML     = "html"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+type Document struct {
+	ProjectID  string         `json:"project_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+	AppType    string         `json:"app_type,omitempty"`
+	Goal       string         `json:"goal,omitempty"`
+	Provider   string         `json:"provider,omitempty"`
+	Idea       ai.ProjectIdea `json:"idea"`
+	Evolutions []Evolution    `json:"evolutions"`
+}
+
+type Evolution struct {
+	EvolutionID string                 `json:"evolution_id"`
+	CreatedAt   time.Time              `json:"created_at"`
+	Evolution   *ai.ProjectEvolution   `json:"evolution,omitempty"`
+	NextPhase   *ai.NextPhaseEvolution `json:"next_phase,omitempty"`
+}
+
+type Exporter struct {
+	format      string
+	templateDir string
+}
+
+func ParseFormat(v string) (string, error) {
+	switch f := strings.ToLower(strings.TrimSpace(v)); f {
+	case FormatMarkdown, "markdown":
+		return FormatMarkdown, nil
+	case FormatJSON, FormatHTML:
+		return f, nil
+	case FormatYAML, "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("export: unsupported format %q (want md|json|yaml|html)", v)
+	}
+}
+
+// NewExporter renders documents in format. Templates named project.<format>.tmpl
+// found in templateDir take precedence over the embedded defaults.
+func NewExporter(format string, templateDir string) (*Exporter, error) {
+	f, err := ParseFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{format: f, templateDir: strings.TrimSpace(templateDir)}, nil
+}
+
+func (e *Exporter) Format() string { return e.format }
+
+func (e *Exporter) FileName(doc Document) string {
+	name := slugify(doc.Idea.Project.Name)
+	if name == "" {
+		name = "project"
+	}
+	id := doc.ProjectID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	if id != "" {
+		name += "-" + id
+	}
+	return name + "." + e.format
+}
+
+func (e *Exporter) Render(w io.Writer, doc Document) error {
+	if doc.Evolutions == nil {
+		doc.Evolutions = []Evolution{}
+	}
+	switch e.format {
+	case FormatJSON:
+		b, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return fmt.Errorf("export: marshal json: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case FormatYAML:
+		return renderYAML(w, doc)
+	case FormatHTML:
+		src, err := e.templateSource()
+		if err != nil {
+			return err
+		}
+		tmpl, err := htmltemplate.New("project").Funcs(htmltemplate.FuncMap(templateFuncs)).Parse(src)
+		if err != nil {
+			return fmt.Errorf("export: parse template: %w", err)
+		}
+		return tmpl.Execute(w, doc)
+	default:
+		src, err := e.templateSource()
+		if err != nil {
+			return err
+		}
+		tmpl, err := texttemplate.New("project").Funcs(texttemplate.FuncMap(templateFuncs)).Parse(src)
+		if err != nil {
+			return fmt.Errorf("export: parse template: %w", err)
+		}
+		return tmpl.Execute(w, doc)
+	}
+}
+
+func (e *Exporter) WriteFile(dir string, doc Document) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("export: create output dir: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := e.Render(&buf, doc); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, e.FileName(doc))
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", fmt.Errorf("export: write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+func (e *Exporter) templateSource() (string, error) {
+	name := "project." + e.format + ".tmpl"
+	if e.templateDir != "" {
+		b, err := os.ReadFile(filepath.Join(e.templateDir, name))
+		if err == nil {
+			return string(b), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("export: read template %s: %w", name, err)
+		}
+	}
+	b, err := defaultTemplates.ReadFile("templates/" + name)
+	if err != nil {
+		return "", fmt.Errorf("export: no template for format %s", e.format)
+	}
+	return string(b), nil
+}
+
+// renderYAML goes through JSON so field names match the json export.
+func renderYAML(w io.Writer, doc Document) error {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("export: marshal yaml: %w", err)
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return fmt.Errorf("export: marshal yaml: %w", err)
+	}
+	clearStyle(&node)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return fmt.Errorf("export: marshal yaml: %w", err)
+	}
+	return enc.Close()
+}
+
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearStyle(c)
+	}
+}
+
+var templateFuncs = map[string]any{
+	"inc": func(i int) int { return i + 1 },
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Local().Format("2006-01-02")
+	},
+	"join": strings.Join,
+	"trim": strings.TrimSpace,
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slugify(s string) string {
+	s = nonSlug.ReplaceAllString(strings.ToLower(strings.TrimSpace(s)), "-")
+	s = strings.Trim(s, "-")
+	if len(s) > 60 {
+		s = strings.Trim(s[:60], "-")
+	}
+	return s
+}
@@ -0,0 +1,337 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"quibit/internal/db"
+	"quibit/internal/persistence/models"
+)
+
+type GormStore struct {
+	db *gorm.DB
+}
+
+func Open(ctx context.Context) (*GormStore, error) {
+	gdb, err := db.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewGormStore(gdb)
+}
+
+func NewGormStore(gdb *gorm.DB) (*GormStore, error) {
+	if gdb == nil {
+		return nil, fmt.Errorf("store: db is nil")
+	}
+	return &GormStore{db: gdb}, nil
+}
+
+func (s *GormStore) DB() *gorm.DB { return s.db }
+
+func (s *GormStore) Close() error {
+	if s == nil || s.db == nil {
+		return nil
+	}
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return fmt.Errorf("store: get sql db: %w", err)
+	}
+	return sqlDB.Close()
+}
+
+func (s *GormStore) SaveProject(ctx context.Context, record ProjectRecord) error {
+	tx := s.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("save project: begin transaction: %w", tx.Error)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := tx.Create(&record.Project).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrDuplicateDNA
+		}
+		return fmt.Errorf("save project: %w", err)
+	}
+	if len(record.Features) > 0 {
+		if err := tx.Create(&record.Features).Error; err != nil {
+			return fmt.Errorf("save project features: %w", err)
+		}
+	}
+	if err := tx.Create(&record.Meta).Error; err != nil {
+		return fmt.Errorf("save project meta: %w", err)
+	}
+	if err := tx.Create(&record.DNA).Error; err != nil {
+		return fmt.Errorf("save project dna: %w", err)
+	}
+	if record.Similarity != nil {
+		if err := tx.Create(record.Similarity).Error; err != nil {
+			return fmt.Errorf("save project similarity: %w", err)
+		}
+	}
+	if record.Embedding != nil {
+		if err := tx.Create(record.Embedding).Error; err != nil {
+			return fmt.Errorf("save project embedding: %w", err)
+		}
+	}
+	if err := createLSHBuckets(tx, map[uuid.UUID][]string{record.Project.ID: record.LSHBuckets}); err != nil {
+		return fmt.Errorf("save lsh buckets: %w", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("save project: commit: %w", err)
+	}
+	return nil
+}
+
+func (s *GormStore) GetProject(ctx context.Context, id uuid.UUID) (models.Project, error) {
+	var row models.Project
+	err := s.db.WithContext(ctx).Where("id = ?", id).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Project{}, ErrNotFound
+	}
+	if err != nil {
+		return models.Project{}, fmt.Errorf("load project: %w", err)
+	}
+	return row, nil
+}
+
+func (s *GormStore) ListRecentProjects(ctx context.Context, limit int) ([]models.Project, error) {
+	var rows []models.Project
+	q := s.db.WithContext(ctx).Order("created_at desc")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load projects: %w", err)
+	}
+	return rows, nil
+}
+
+func (s *GormStore) ListProjectsByID(ctx context.Context, ids []uuid.UUID) ([]models.Project, error) {
+	var rows []models.Project
+	if len(ids) == 0 {
+		return rows, nil
+	}
+	if err := s.db.WithContext(ctx).Where("id IN ?", ids).Order("created_at desc").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load projects: %w", err)
+	}
+	return rows, nil
+}
+
+func (s *GormStore) SaveEvolution(ctx context.Context, evolution models.ProjectEvolution) error {
+	if err := s.db.WithContext(ctx).Create(&evolution).Error; err != nil {
+		return fmt.Errorf("save evolution: %w", err)
+	}
+	return nil
+}
+
+func (s *GormStore) ListEvolutions(ctx context.Context, projectID uuid.UUID) ([]models.ProjectEvolution, error) {
+	var rows []models.ProjectEvolution
+	if err := s.db.WithContext(ctx).Where("project_id = ?", projectID).Order("created_at asc").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load evolutions: %w", err)
+	}
+	return rows, nil
+}
+
+// SaveEvolutionPhase stores the evolution together with the scope version it
+// produces; the version number is assigned here.
+func (s *GormStore) SaveEvolutionPhase(ctx context.Context, evolution models.ProjectEvolution, scope models.ProjectScopeVersion) (models.ProjectScopeVersion, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&evolution).Error; err != nil {
+			return fmt.Errorf("save evolution: %w", err)
+		}
+		var latest int
+		if err := tx.Model(&models.ProjectScopeVersion{}).Where("project_id = ?", evolution.ProjectID).Select("COALESCE(MAX(version), 0)").Scan(&latest).Error; err != nil {
+			return fmt.Errorf("save scope version: %w", err)
+		}
+		scope.ProjectID = evolution.ProjectID
+		scope.Version = latest + 1
+		scope.EvolutionID = &evolution.ID
+		if err := tx.Create(&scope).Error; err != nil {
+			return fmt.Errorf("save scope version: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.ProjectScopeVersion{}, err
+	}
+	return scope, nil
+}
+
+func (s *GormStore) ListScopeVersions(ctx context.Context, projectID uuid.UUID) ([]models.ProjectScopeVersion, error) {
+	var rows []models.ProjectScopeVersion
+	if err := s.db.WithContext(ctx).Where("project_id = ?", projectID).Order("version asc").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load scope versions: %w", err)
+	}
+	return rows, nil
+}
+
+func (s *GormStore) SaveProjectDNA(ctx context.Context, dna models.ProjectDNA) error {
+	err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}},
+		UpdateAll: true,
+	}).Create(&dna).Error
+	if err != nil {
+		return fmt.Errorf("save project dna: %w", err)
+	}
+	return nil
+}
+
+func (s *GormStore) GetProjectDNA(ctx context.Context, projectID uuid.UUID) (models.ProjectDNA, error) {
+	var row models.ProjectDNA
+	err := s.db.WithContext(ctx).Where("project_id = ?", projectID).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ProjectDNA{}, ErrNotFound
+	}
+	if err != nil {
+		return models.ProjectDNA{}, fmt.Errorf("load project dna: %w", err)
+	}
+	return row, nil
+}
+
+func (s *GormStore) SaveReadinessReview(ctx context.Context, review models.ProjectReadinessReview) error {
+	if err := s.db.WithContext(ctx).Create(&review).Error; err != nil {
+		return fmt.Errorf("save readiness review: %w", err)
+	}
+	return nil
+}
+
+func (s *GormStore) ListReadinessReviews(ctx context.Context, projectID uuid.UUID) ([]models.ProjectReadinessReview, error) {
+	var rows []models.ProjectReadinessReview
+	if err := s.db.WithContext(ctx).Where("project_id = ?", projectID).Order("created_at asc").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load readiness reviews: %w", err)
+	}
+	return rows, nil
+}
+
+func (s *GormStore) ListSimilarities(ctx context.Context, projectID uuid.UUID) ([]models.ProjectSimilarity, error) {
+	var rows []models.ProjectSimilarity
+	if err := s.db.WithContext(ctx).Where("project_id = ?", projectID).Order("similarity_score desc").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load project similarity: %w", err)
+	}
+	return rows, nil
+}
+
+func (s *GormStore) SaveEmbeddings(ctx context.Context, embeddings []models.ProjectEmbedding) error {
+	if len(embeddings) == 0 {
+		return nil
+	}
+	err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "project_id"}, {Name: "model"}},
+		UpdateAll: true,
+	}).Create(&embeddings).Error
+	if err != nil {
+		return fmt.Errorf("save project embeddings: %w", err)
+	}
+	return nil
+}
+
+func (s *GormStore) ListEmbeddings(ctx context.Context, model string, projectIDs []uuid.UUID) ([]models.ProjectEmbedding, error) {
+	var rows []models.ProjectEmbedding
+	if len(projectIDs) == 0 {
+		return rows, nil
+	}
+	if err := s.db.WithContext(ctx).Where("model = ? AND project_id IN ?", model, projectIDs).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("load project embeddings: %w", err)
+	}
+	return rows, nil
+}
+
+// RebuildLSHIndex replaces the whole index in one transaction.
+func (s *GormStore) RebuildLSHIndex(ctx context.Context, buckets map[uuid.UUID][]string) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.ProjectLSHBucket{}).Error; err != nil {
+			return err
+		}
+		return createLSHBuckets(tx, buckets)
+	})
+	if err != nil {
+		return fmt.Errorf("rebuild lsh index: %w", err)
+	}
+	return nil
+}
+
+func createLSHBuckets(tx *gorm.DB, buckets map[uuid.UUID][]string) error {
+	var rows []models.ProjectLSHBucket
+	for projectID, keys := range buckets {
+		for _, key := range keys {
+			rows = append(rows, models.ProjectLSHBucket{ProjectID: projectID, Bucket: key})
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&rows, 500).Error
+}
+
+func (s *GormStore) FindLSHCandidates(ctx context.Context, buckets []string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if len(buckets) == 0 {
+		return ids, nil
+	}
+	err := s.db.WithContext(ctx).Model(&models.ProjectLSHBucket{}).
+		Where("bucket IN ?", buckets).
+		Distinct("project_id").
+		Pluck("project_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("find lsh candidates: %w", err)
+	}
+	return ids, nil
+}
+
+// AddJSONRepairCounts adds counts to the running totals per repair path.
+func (s *GormStore) AddJSONRepairCounts(ctx context.Context, counts map[string]int64) error {
+	now := time.Now().UTC()
+	rows := make([]models.JSONRepairStat, 0, len(counts))
+	for path, n := range counts {
+		if n > 0 {
+			rows = append(rows, models.JSONRepairStat{Path: path, Count: n, UpdatedAt: now})
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "path"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"count":      gorm.Expr("json_repair_stats.count + excluded.count"),
+			"updated_at": now,
+		}),
+	}).Create(&rows).Error
+	if err != nil {
+		return fmt.Errorf("add json repair counts: %w", err)
+	}
+	return nil
+}
+
+func (s *GormStore) ListJSONRepairStats(ctx context.Context) ([]models.JSONRepairStat, error) {
+	var rows []models.JSONRepairStat
+	if err := s.db.WithContext(ctx).Order("count DESC, path").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list json repair stats: %w", err)
+	}
+	return rows, nil
+}
+
+func isUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	s := strings.ToLower(err.Error())
+	if strings.Contains(s, "sqlstate 23505") {
+		return true
+	}
+	if strings.Contains(s, "duplicate key") {
+		return true
+	}
+	if strings.Contains(s, "unique constraint") {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,330 @@
+package persistence
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"quibit/internal/persistence/models"
+)
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore keeps everything in maps. It backs tests that run commands
+// without a database.
+type MemoryStore struct {
+	mu         sync.RWMutex
+	projects   map[uuid.UUID]models.Project
+	dnaHashes  map[string]uuid.UUID
+	features   map[uuid.UUID][]models.ProjectFeature
+	meta       map[uuid.UUID]models.ProjectMeta
+	evolutions map[uuid.UUID][]models.ProjectEvolution
+	readiness  map[uuid.UUID][]models.ProjectReadinessReview
+	scopes     map[uuid.UUID][]models.ProjectScopeVersion
+	dna        map[uuid.UUID]models.ProjectDNA
+	similarity map[uuid.UUID][]models.ProjectSimilarity
+	embeddings map[string]map[uuid.UUID]models.ProjectEmbedding
+	lsh        map[uuid.UUID][]string
+	repairs    map[string]models.JSONRepairStat
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		projects:   map[uuid.UUID]models.Project{},
+		dnaHashes:  map[string]uuid.UUID{},
+		features:   map[uuid.UUID][]models.ProjectFeature{},
+		meta:       map[uuid.UUID]models.ProjectMeta{},
+		evolutions: map[uuid.UUID][]models.ProjectEvolution{},
+		readiness:  map[uuid.UUID][]models.ProjectReadinessReview{},
+		scopes:     map[uuid.UUID][]models.ProjectScopeVersion{},
+		dna:        map[uuid.UUID]models.ProjectDNA{},
+		similarity: map[uuid.UUID][]models.ProjectSimilarity{},
+		embeddings: map[string]map[uuid.UUID]models.ProjectEmbedding{},
+		lsh:        map[uuid.UUID][]string{},
+		repairs:    map[string]models.JSONRepairStat{},
+	}
+}
+
+func (s *MemoryStore) Close() error { return nil }
+
+func (s *MemoryStore) SaveProject(ctx context.Context, record ProjectRecord) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p := record.Project
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.dnaHashes[p.DNAHash]; exists {
+		return ErrDuplicateDNA
+	}
+	s.projects[p.ID] = p
+	s.dnaHashes[p.DNAHash] = p.ID
+	s.features[p.ID] = append([]models.ProjectFeature(nil), record.Features...)
+	s.meta[p.ID] = record.Meta
+	s.dna[p.ID] = record.DNA
+	if record.Similarity != nil {
+		s.similarity[p.ID] = append(s.similarity[p.ID], *record.Similarity)
+	}
+	if e := record.Embedding; e != nil {
+		if s.embeddings[e.Model] == nil {
+			s.embeddings[e.Model] = map[uuid.UUID]models.ProjectEmbedding{}
+		}
+		s.embeddings[e.Model][p.ID] = *e
+	}
+	s.lsh[p.ID] = append([]string(nil), record.LSHBuckets...)
+	return nil
+}
+
+func (s *MemoryStore) GetProject(ctx context.Context, id uuid.UUID) (models.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return models.Project{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.projects[id]
+	if !ok {
+		return models.Project{}, ErrNotFound
+	}
+	return p, nil
+}
+
+func (s *MemoryStore) ListRecentProjects(ctx context.Context, limit int) ([]models.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	rows := make([]models.Project, 0, len(s.projects))
+	for _, p := range s.projects {
+		rows = append(rows, p)
+	}
+	s.mu.RUnlock()
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].CreatedAt.After(rows[j].CreatedAt) })
+	if limit > 0 && len(rows) > limit {
+		rows = rows[:limit]
+	}
+	return rows, nil
+}
+
+func (s *MemoryStore) SaveEvolution(ctx context.Context, evolution models.ProjectEvolution) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evolutions[evolution.ProjectID] = append(s.evolutions[evolution.ProjectID], evolution)
+	return nil
+}
+
+func (s *MemoryStore) ListEvolutions(ctx context.Context, projectID uuid.UUID) ([]models.ProjectEvolution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	rows := append([]models.ProjectEvolution(nil), s.evolutions[projectID]...)
+	s.mu.RUnlock()
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].CreatedAt.Before(rows[j].CreatedAt) })
+	return rows, nil
+}
+
+func (s *MemoryStore) SaveEvolutionPhase(ctx context.Context, evolution models.ProjectEvolution, scope models.ProjectScopeVersion) (models.ProjectScopeVersion, error) {
+	if err := ctx.Err(); err != nil {
+		return models.ProjectScopeVersion{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evolutions[evolution.ProjectID] = append(s.evolutions[evolution.ProjectID], evolution)
+	scope.ProjectID = evolution.ProjectID
+	scope.Version = len(s.scopes[evolution.ProjectID]) + 1
+	scope.EvolutionID = &evolution.ID
+	s.scopes[evolution.ProjectID] = append(s.scopes[evolution.ProjectID], scope)
+	return scope, nil
+}
+
+func (s *MemoryStore) ListScopeVersions(ctx context.Context, projectID uuid.UUID) ([]models.ProjectScopeVersion, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]models.ProjectScopeVersion(nil), s.scopes[projectID]...), nil
+}
+
+func (s *MemoryStore) SaveProjectDNA(ctx context.Context, dna models.ProjectDNA) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dna[dna.ProjectID] = dna
+	return nil
+}
+
+func (s *MemoryStore) GetProjectDNA(ctx context.Context, projectID uuid.UUID) (models.ProjectDNA, error) {
+	if err := ctx.Err(); err != nil {
+		return models.ProjectDNA{}, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	dna, ok := s.dna[projectID]
+	if !ok {
+		return models.ProjectDNA{}, ErrNotFound
+	}
+	return dna, nil
+}
+
+func (s *MemoryStore) SaveReadinessReview(ctx context.Context, review models.ProjectReadinessReview) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readiness[review.ProjectID] = append(s.readiness[review.ProjectID], review)
+	return nil
+}
+
+func (s *MemoryStore) ListReadinessReviews(ctx context.Context, projectID uuid.UUID) ([]models.ProjectReadinessReview, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	rows := append([]models.ProjectReadinessReview(nil), s.readiness[projectID]...)
+	s.mu.RUnlock()
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].CreatedAt.Before(rows[j].CreatedAt) })
+	return rows, nil
+}
+
+func (s *MemoryStore) ListSimilarities(ctx context.Context, projectID uuid.UUID) ([]models.ProjectSimilarity, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	rows := append([]models.ProjectSimilarity(nil), s.similarity[projectID]...)
+	s.mu.RUnlock()
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].SimilarityScore > rows[j].SimilarityScore })
+	return rows, nil
+}
+
+func (s *MemoryStore) SaveEmbeddings(ctx context.Context, embeddings []models.ProjectEmbedding) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range embeddings {
+		byProject, ok := s.embeddings[e.Model]
+		if !ok {
+			byProject = map[uuid.UUID]models.ProjectEmbedding{}
+			s.embeddings[e.Model] = byProject
+		}
+		byProject[e.ProjectID] = e
+	}
+	return nil
+}
+
+func (s *MemoryStore) ListEmbeddings(ctx context.Context, model string, projectIDs []uuid.UUID) ([]models.ProjectEmbedding, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rows := make([]models.ProjectEmbedding, 0, len(projectIDs))
+	for _, id := range projectIDs {
+		if e, ok := s.embeddings[model][id]; ok {
+			rows = append(rows, e)
+		}
+	}
+	return rows, nil
+}
+
+func (s *MemoryStore) ListProjectsByID(ctx context.Context, ids []uuid.UUID) ([]models.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	rows := make([]models.Project, 0, len(ids))
+	for _, id := range ids {
+		if p, ok := s.projects[id]; ok {
+			rows = append(rows, p)
+		}
+	}
+	s.mu.RUnlock()
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].CreatedAt.After(rows[j].CreatedAt) })
+	return rows, nil
+}
+
+func (s *MemoryStore) RebuildLSHIndex(ctx context.Context, buckets map[uuid.UUID][]string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lsh = make(map[uuid.UUID][]string, len(buckets))
+	for id, keys := range buckets {
+		s.lsh[id] = append([]string(nil), keys...)
+	}
+	return nil
+}
+
+func (s *MemoryStore) FindLSHCandidates(ctx context.Context, buckets []string) ([]uuid.UUID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	want := make(map[string]struct{}, len(buckets))
+	for _, b := range buckets {
+		want[b] = struct{}{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var ids []uuid.UUID
+	for id, keys := range s.lsh {
+		for _, k := range keys {
+			if _, ok := want[k]; ok {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (s *MemoryStore) AddJSONRepairCounts(ctx context.Context, counts map[string]int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for path, n := range counts {
+		if n <= 0 {
+			continue
+		}
+		row := s.repairs[path]
+		row.Path = path
+		row.Count += n
+		row.UpdatedAt = now
+		s.repairs[path] = row
+	}
+	return nil
+}
+
+func (s *MemoryStore) ListJSONRepairStats(ctx context.Context) ([]models.JSONRepairStat, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	rows := make([]models.JSONRepairStat, 0, len(s.repairs))
+	for _, row := range s.repairs {
+		rows = append(rows, row)
+	}
+	s.mu.RUnlock()
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Path < rows[j].Path
+	})
+	return rows, nil
+}
@@ -3,25 +3,395 @@ package persistence
 import (
 	"context"
 	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
-	"quibit/internal/persistence/models"
+	"quibit/internal/persistence/migrations"
 )
 
-func AutoMigrate(ctx context.Context, db *gorm.DB) error {
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+
+	// UpFunc and DownFunc replace the SQL scripts for steps that need to
+	// inspect the live schema first.
+	UpFunc   func(tx *gorm.DB) error
+	DownFunc func(tx *gorm.DB) error
+
+	// Prepare runs before Up in the same transaction, for scripted steps
+	// that depend on schema state SQL alone cannot detect.
+	Prepare func(tx *gorm.DB) error
+}
+
+// goMigrations without a DownFunc are irreversible: migrate down stops there
+// instead of forgetting a schema change it cannot undo.
+var goMigrations = []Migration{
+	// Which column name a database had before 0005 depends on how it was
+	// created, so there is no previous state to restore.
+	{Version: 5, Name: "ai_provider_column", UpFunc: reconcileAIProviderColumn},
+}
+
+// prepareSteps attach a Prepare hook to the scripted migration with the same
+// version, in every dialect.
+var prepareSteps = map[int]func(tx *gorm.DB) error{
+	// The entities schema used the names project_dna and project_similarity
+	// for tables of a different shape, so they must be moved aside before
+	// 0004 and 0006 create theirs with IF NOT EXISTS.
+	2: moveLegacyTables,
+}
+
+type MigrationState struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type schemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+func LoadMigrations(dialect string) ([]Migration, error) {
+	dir := migrationDir(dialect)
+	entries, err := fs.ReadDir(migrations.FS, dir)
+	if err != nil {
+		return nil, fmt.Errorf("migrations: unsupported dialect %q", dialect)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		name := e.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		prefix, label, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migrations: invalid file name %s", name)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migrations: invalid version in %s", name)
+		}
+		b, err := fs.ReadFile(migrations.FS, path.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("migrations: read %s: %w", name, err)
+		}
+
+		m := byVersion[version]
+		if m == nil {
+			m = &Migration{Version: version, Name: label}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(b)
+		} else {
+			m.Down = string(b)
+		}
+	}
+
+	for _, g := range goMigrations {
+		if _, ok := byVersion[g.Version]; ok {
+			return nil, fmt.Errorf("migrations: %04d_%s is defined twice", g.Version, g.Name)
+		}
+		g := g
+		byVersion[g.Version] = &g
+	}
+
+	for version, prepare := range prepareSteps {
+		m, ok := byVersion[version]
+		if !ok {
+			return nil, fmt.Errorf("migrations: prepare step for %04d has no scripted migration", version)
+		}
+		m.Prepare = prepare
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" && m.UpFunc == nil {
+			return nil, fmt.Errorf("migrations: %04d_%s has no up migration", m.Version, m.Name)
+		}
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+func MigrationStatus(ctx context.Context, db *gorm.DB) ([]MigrationState, error) {
+	all, err := LoadMigrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]MigrationState, 0, len(all))
+	for _, m := range all {
+		st := MigrationState{Migration: m}
+		if row, ok := applied[m.Version]; ok {
+			at := row.AppliedAt
+			st.AppliedAt = &at
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+func MigrateUp(ctx context.Context, db *gorm.DB) ([]Migration, error) {
+	if db == nil {
+		return nil, fmt.Errorf("migrate up: db is nil")
+	}
+	all, err := LoadMigrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, m := range all {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if m.Prepare != nil {
+				if err := m.Prepare(tx); err != nil {
+					return err
+				}
+			}
+			if err := m.apply(tx, m.Up, m.UpFunc); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migrate up: %04d_%s: %w", m.Version, m.Name, err)
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+func MigrateDown(ctx context.Context, db *gorm.DB, steps int) ([]Migration, error) {
 	if db == nil {
-		return fmt.Errorf("auto-migrate: db is nil")
+		return nil, fmt.Errorf("migrate down: db is nil")
+	}
+	if steps <= 0 {
+		steps = 1
+	}
+	all, err := LoadMigrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(all) - 1; i >= 0 && len(done) < steps; i-- {
+		m := all[i]
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+		if strings.TrimSpace(m.Down) == "" && m.DownFunc == nil {
+			return done, fmt.Errorf("migrate down: %04d_%s is irreversible", m.Version, m.Name)
+		}
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := m.apply(tx, m.Down, m.DownFunc); err != nil {
+				return err
+			}
+			return tx.Where("version = ?", m.Version).Delete(&schemaMigration{}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migrate down: %04d_%s: %w", m.Version, m.Name, err)
+		}
+		done = append(done, m)
 	}
+	return done, nil
+}
 
-	if err := db.WithContext(ctx).AutoMigrate(
-		&models.Project{},
-		&models.ProjectFeature{},
-		&models.ProjectMeta{},
-		&models.ProjectEvolution{},
-	); err != nil {
-		return fmt.Errorf("auto-migrate: %w", err)
+func appliedMigrations(ctx context.Context, db *gorm.DB) (map[int]schemaMigration, error) {
+	const ddl = "CREATE TABLE IF NOT EXISTS schema_migrations (version bigint PRIMARY KEY, name text NOT NULL, applied_at timestamp NOT NULL)"
+	if err := db.WithContext(ctx).Exec(ddl).Error; err != nil {
+		return nil, fmt.Errorf("migrations: create schema_migrations: %w", err)
+	}
+	var rows []schemaMigration
+	if err := db.WithContext(ctx).Order("version asc").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("migrations: load schema_migrations: %w", err)
+	}
+	out := make(map[int]schemaMigration, len(rows))
+	for _, r := range rows {
+		out[r.Version] = r
 	}
+	return out, nil
+}
 
+func migrationDir(dialect string) string {
+	switch strings.ToLower(strings.TrimSpace(dialect)) {
+	case "postgres", "pgx":
+		return "postgres"
+	default:
+		return strings.ToLower(strings.TrimSpace(dialect))
+	}
+}
+
+func (m Migration) apply(tx *gorm.DB, script string, fn func(tx *gorm.DB) error) error {
+	if fn != nil {
+		return fn(tx)
+	}
+	return execScript(tx, script)
+}
+
+// reconcileAIProviderColumn moves databases created by GORM AutoMigrate, which
+// named the column a_iprovider, onto the ai_provider column used since the
+// baseline migration.
+func reconcileAIProviderColumn(tx *gorm.DB) error {
+	m := tx.Migrator()
+	if !m.HasColumn("projects", "a_iprovider") {
+		return nil
+	}
+	if !m.HasColumn("projects", "ai_provider") {
+		return tx.Exec("ALTER TABLE projects RENAME COLUMN a_iprovider TO ai_provider").Error
+	}
+	if err := tx.Exec("UPDATE projects SET ai_provider = a_iprovider WHERE ai_provider IS NULL OR ai_provider = ''").Error; err != nil {
+		return err
+	}
+	return tx.Exec("ALTER TABLE projects DROP COLUMN a_iprovider").Error
+}
+
+// legacyTables lists the entities-era tables that the models schema either
+// reuses the name of with a different shape or no longer reads, with the
+// indexes AutoMigrate gave them.
+var legacyTables = []struct {
+	Name    string
+	Indexes []string
+	IsStale func(m gorm.Migrator) bool
+}{
+	{
+		Name:    "generations",
+		Indexes: []string{"generations_pkey", "idx_generations_project_id"},
+		IsStale: func(gorm.Migrator) bool { return true },
+	},
+	{
+		Name:    "project_dna",
+		Indexes: []string{"project_dna_pkey", "idx_project_dna_project_id", "idx_project_dna_dna_hash"},
+		IsStale: func(m gorm.Migrator) bool { return m.HasColumn("project_dna", "dna_hash") },
+	},
+	{
+		Name:    "project_similarity",
+		Indexes: []string{"project_similarity_pkey", "idx_project_similarity_project_id", "idx_project_similarity_compared_project_id"},
+		IsStale: func(m gorm.Migrator) bool { return !m.HasColumn("project_similarity", "dominant_dimension") },
+	},
+}
+
+// moveLegacyTables renames entities-era tables, and the indexes whose names
+// the models schema reuses, to *_legacy so their rows stay readable without
+// blocking later migrations.
+func moveLegacyTables(tx *gorm.DB) error {
+	m := tx.Migrator()
+	for _, t := range legacyTables {
+		if !m.HasTable(t.Name) || !t.IsStale(m) {
+			continue
+		}
+		renamed := t.Name + "_legacy"
+		if m.HasTable(renamed) {
+			return fmt.Errorf("legacy table %s: %s already exists", t.Name, renamed)
+		}
+		if err := m.RenameTable(t.Name, renamed); err != nil {
+			return fmt.Errorf("legacy table %s: %w", t.Name, err)
+		}
+		for _, idx := range t.Indexes {
+			if !m.HasIndex(renamed, idx) {
+				continue
+			}
+			if err := m.RenameIndex(renamed, idx, strings.Replace(idx, t.Name, renamed, 1)); err != nil {
+				return fmt.Errorf("legacy table %s: rename index %s: %w", t.Name, idx, err)
+			}
+		}
+	}
 	return nil
 }
+
+func execScript(tx *gorm.DB, script string) error {
+	for _, stmt := range splitStatements(script) {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitStatements splits on top-level semicolons, keeping $$-quoted bodies,
+// string literals and -- comments intact.
+func splitStatements(script string) []string {
+	var out []string
+	var cur strings.Builder
+	inDollar, inString, inComment := false, false, false
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case inComment:
+			if c == '\n' {
+				inComment = false
+			}
+			continue
+		case inString:
+			if c == '\'' {
+				inString = false
+			}
+		case inDollar:
+			if c == '$' && i+1 < len(script) && script[i+1] == '$' {
+				inDollar = false
+				cur.WriteString("$$")
+				i++
+				continue
+			}
+		default:
+			switch {
+			case c == '-' && i+1 < len(script) && script[i+1] == '-':
+				inComment = true
+				continue
+			case c == '\'':
+				inString = true
+			case c == '$' && i+1 < len(script) && script[i+1] == '$':
+				inDollar = true
+				cur.WriteString("$$")
+				i++
+				continue
+			case c == ';':
+				if s := strings.TrimSpace(cur.String()); s != "" {
+					out = append(out, s)
+				}
+				cur.Reset()
+				continue
+			}
+		}
+		cur.WriteByte(c)
+	}
+	if s := strings.TrimSpace(cur.String()); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMigrateUpMovesLegacyTables(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The tables AutoMigrate created from the entities package.
+	legacy := []string{
+		"CREATE TABLE generations (id text PRIMARY KEY, project_id text NOT NULL, prompt text, result text, created_at datetime NOT NULL)",
+		"CREATE INDEX idx_generations_project_id ON generations (project_id)",
+		"CREATE TABLE project_dna (id text PRIMARY KEY, project_id text NOT NULL, dna_hash text NOT NULL, created_at datetime NOT NULL)",
+		"CREATE INDEX idx_project_dna_project_id ON project_dna (project_id)",
+		"CREATE UNIQUE INDEX idx_project_dna_dna_hash ON project_dna (dna_hash)",
+		"CREATE TABLE project_similarity (id text PRIMARY KEY, project_id text NOT NULL, compared_project_id text NOT NULL, similarity_score real NOT NULL, created_at datetime NOT NULL)",
+		"CREATE INDEX idx_project_similarity_project_id ON project_similarity (project_id)",
+		"CREATE INDEX idx_project_similarity_compared_project_id ON project_similarity (compared_project_id)",
+		"INSERT INTO project_dna VALUES ('d1', 'p1', 'hash', '2024-01-01')",
+	}
+	for _, stmt := range legacy {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if _, err := MigrateUp(context.Background(), db); err != nil {
+		t.Fatalf("MigrateUp: %v", err)
+	}
+
+	m := db.Migrator()
+	for _, table := range []string{"generations_legacy", "project_dna_legacy", "project_similarity_legacy"} {
+		if !m.HasTable(table) {
+			t.Errorf("%s is missing", table)
+		}
+	}
+	if m.HasTable("generations") {
+		t.Error("generations was not moved aside")
+	}
+	if !m.HasColumn("project_dna", "fingerprint") || m.HasColumn("project_dna", "dna_hash") {
+		t.Error("project_dna does not have the models shape")
+	}
+	if !m.HasColumn("project_similarity", "dominant_dimension") {
+		t.Error("project_similarity does not have the models shape")
+	}
+	if !m.HasIndex("project_similarity", "idx_project_similarity_project_id") {
+		t.Error("idx_project_similarity_project_id is not on the new project_similarity table")
+	}
+	var kept int64
+	if err := db.Table("project_dna_legacy").Where("dna_hash = ?", "hash").Count(&kept).Error; err != nil || kept != 1 {
+		t.Errorf("legacy dna rows = %d (err %v), want 1", kept, err)
+	}
+}
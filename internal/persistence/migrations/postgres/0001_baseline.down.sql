@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS project_evolutions;
+DROP TABLE IF EXISTS project_meta;
+DROP TABLE IF EXISTS project_features;
+DROP TABLE IF EXISTS projects;
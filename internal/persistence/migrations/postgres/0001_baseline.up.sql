@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id uuid PRIMARY KEY,
+    title text NOT NULL,
+    summary text NOT NULL,
+    dna_hash text NOT NULL,
+    similarity_score double precision NOT NULL DEFAULT 0,
+    similar_project_id uuid,
+    pivot_reason text,
+    project_overview text NOT NULL,
+    project_kind text,
+    mvp_scope jsonb NOT NULL,
+    tech_stack jsonb NOT NULL,
+    raw_ai_output jsonb NOT NULL,
+    app_type text NOT NULL,
+    goal text NOT NULL,
+    complexity text NOT NULL,
+    duration text NOT NULL,
+    ai_provider text NOT NULL,
+    provider_used text NOT NULL,
+    fallback_used boolean NOT NULL DEFAULT false,
+    provider_error text,
+    latency_ms bigint NOT NULL DEFAULT 0,
+    retry_reason text,
+    created_at timestamptz NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS project_features (
+    id uuid PRIMARY KEY,
+    project_id uuid NOT NULL,
+    type text NOT NULL,
+    description text NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_features_project_id ON project_features (project_id);
+
+CREATE TABLE IF NOT EXISTS project_meta (
+    project_id uuid PRIMARY KEY,
+    target_users jsonb NOT NULL,
+    tech_stack text NOT NULL,
+    raw_ai_output jsonb NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS project_evolutions (
+    id uuid PRIMARY KEY,
+    project_id uuid NOT NULL,
+    raw_ai_output jsonb NOT NULL,
+    provider_used text NOT NULL,
+    fallback_used boolean NOT NULL DEFAULT false,
+    provider_error text,
+    latency_ms bigint NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_evolutions_project_id ON project_evolutions (project_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_projects_created_at;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS description text;
+UPDATE projects SET description = summary WHERE description IS NULL;
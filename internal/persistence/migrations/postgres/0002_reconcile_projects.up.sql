@@ -0,0 +1,45 @@
+-- Databases migrated by the old entities schema have a thin projects table
+-- (id, title, description, complexity, created_at). Bring it up to the
+-- models schema and fold description into summary. Its generations,
+-- project_dna and project_similarity tables were already renamed to *_legacy
+-- by the Go prepare step that runs before this script.
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS summary text NOT NULL DEFAULT '';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS dna_hash text;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS similarity_score double precision NOT NULL DEFAULT 0;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS similar_project_id uuid;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS pivot_reason text;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS project_overview text NOT NULL DEFAULT '';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS project_kind text;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS mvp_scope jsonb NOT NULL DEFAULT '[]';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS tech_stack jsonb NOT NULL DEFAULT '[]';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS raw_ai_output jsonb NOT NULL DEFAULT '{}';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS app_type text NOT NULL DEFAULT '';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS goal text NOT NULL DEFAULT '';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS duration text NOT NULL DEFAULT '';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS ai_provider text NOT NULL DEFAULT '';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS provider_used text NOT NULL DEFAULT '';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS fallback_used boolean NOT NULL DEFAULT false;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS provider_error text;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS latency_ms bigint NOT NULL DEFAULT 0;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS retry_reason text;
+
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'projects' AND column_name = 'description'
+    ) THEN
+        UPDATE projects SET summary = COALESCE(description, '') WHERE summary = '';
+        ALTER TABLE projects DROP COLUMN description;
+    END IF;
+END $$;
+
+UPDATE projects SET dna_hash = 'legacy:' || id::text WHERE dna_hash IS NULL OR dna_hash = '';
+UPDATE projects SET complexity = '' WHERE complexity IS NULL;
+UPDATE projects SET project_overview = title WHERE project_overview = '';
+
+ALTER TABLE projects ALTER COLUMN dna_hash SET NOT NULL;
+ALTER TABLE projects ALTER COLUMN complexity SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_dna_hash ON projects (dna_hash);
+CREATE INDEX IF NOT EXISTS idx_projects_created_at ON projects (created_at);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS project_readiness_reviews (
+    id uuid PRIMARY KEY,
+    project_id uuid NOT NULL,
+    verdict text NOT NULL,
+    built_scope jsonb NOT NULL,
+    raw_ai_output jsonb NOT NULL,
+    provider_used text NOT NULL,
+    fallback_used boolean NOT NULL DEFAULT false,
+    provider_error text,
+    latency_ms bigint NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_readiness_reviews_project_id ON project_readiness_reviews (project_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS project_scope_versions;
+DROP TABLE IF EXISTS project_dna;
+ALTER TABLE project_evolutions DROP COLUMN IF EXISTS contract;
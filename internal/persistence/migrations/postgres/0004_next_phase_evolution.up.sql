@@ -0,0 +1,26 @@
+ALTER TABLE project_evolutions ADD COLUMN IF NOT EXISTS contract text NOT NULL DEFAULT 'project_evolution';
+
+CREATE TABLE IF NOT EXISTS project_dna (
+    project_id uuid PRIMARY KEY,
+    app_type text NOT NULL,
+    primary_domain text NOT NULL,
+    core_tech_stack jsonb NOT NULL,
+    architectural_style text NOT NULL,
+    complexity_level text NOT NULL,
+    fingerprint text NOT NULL,
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_dna_fingerprint ON project_dna (fingerprint);
+
+CREATE TABLE IF NOT EXISTS project_scope_versions (
+    id uuid PRIMARY KEY,
+    project_id uuid NOT NULL,
+    version bigint NOT NULL,
+    evolution_id uuid,
+    must_have jsonb NOT NULL,
+    out_of_scope jsonb NOT NULL,
+    created_at timestamptz NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_project_scope_versions_project_version ON project_scope_versions (project_id, version);
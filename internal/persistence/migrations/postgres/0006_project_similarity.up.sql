@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS project_similarity (
+    id uuid PRIMARY KEY,
+    project_id uuid NOT NULL,
+    compared_project_id uuid NOT NULL,
+    title_score double precision NOT NULL DEFAULT 0,
+    problem_score double precision NOT NULL DEFAULT 0,
+    features_score double precision NOT NULL DEFAULT 0,
+    users_score double precision NOT NULL DEFAULT 0,
+    tech_score double precision NOT NULL DEFAULT 0,
+    complexity_score double precision NOT NULL DEFAULT 0,
+    similarity_score double precision NOT NULL DEFAULT 0,
+    dominant_dimension text NOT NULL,
+    created_at timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_similarity_project_id ON project_similarity (project_id);
@@ -0,0 +1,2 @@
+ALTER TABLE project_similarity DROP COLUMN IF EXISTS semantic_score;
+DROP TABLE IF EXISTS project_embeddings;
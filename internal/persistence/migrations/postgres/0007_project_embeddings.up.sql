@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS project_embeddings (
+    project_id uuid NOT NULL,
+    model text NOT NULL,
+    dims integer NOT NULL,
+    vector jsonb NOT NULL,
+    created_at timestamptz NOT NULL,
+    PRIMARY KEY (project_id, model)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_embeddings_model ON project_embeddings (model);
+
+ALTER TABLE project_similarity ADD COLUMN IF NOT EXISTS semantic_score double precision NOT NULL DEFAULT 0;
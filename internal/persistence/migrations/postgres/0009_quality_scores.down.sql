@@ -0,0 +1,4 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS quality_judgement;
+ALTER TABLE projects DROP COLUMN IF EXISTS judge_quality_score;
+ALTER TABLE projects DROP COLUMN IF EXISTS heuristic_quality_score;
+ALTER TABLE projects DROP COLUMN IF EXISTS quality_score;
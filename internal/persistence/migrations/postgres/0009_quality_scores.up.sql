@@ -0,0 +1,4 @@
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS quality_score double precision;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS heuristic_quality_score double precision;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS judge_quality_score double precision;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS quality_judgement jsonb;
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS json_repair_stats (
+    path text PRIMARY KEY,
+    count bigint NOT NULL DEFAULT 0,
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
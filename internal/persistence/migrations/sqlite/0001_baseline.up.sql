@@ -0,0 +1,56 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id text PRIMARY KEY,
+    title text NOT NULL,
+    summary text NOT NULL,
+    dna_hash text NOT NULL,
+    similarity_score real NOT NULL DEFAULT 0,
+    similar_project_id text,
+    pivot_reason text,
+    project_overview text NOT NULL,
+    project_kind text,
+    mvp_scope text NOT NULL,
+    tech_stack text NOT NULL,
+    raw_ai_output text NOT NULL,
+    app_type text NOT NULL,
+    goal text NOT NULL,
+    complexity text NOT NULL,
+    duration text NOT NULL,
+    ai_provider text NOT NULL,
+    provider_used text NOT NULL,
+    fallback_used numeric NOT NULL DEFAULT false,
+    provider_error text,
+    latency_ms integer NOT NULL DEFAULT 0,
+    retry_reason text,
+    created_at datetime NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_dna_hash ON projects (dna_hash);
+
+CREATE TABLE IF NOT EXISTS project_features (
+    id text PRIMARY KEY,
+    project_id text NOT NULL,
+    type text NOT NULL,
+    description text NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_features_project_id ON project_features (project_id);
+
+CREATE TABLE IF NOT EXISTS project_meta (
+    project_id text PRIMARY KEY,
+    target_users text NOT NULL,
+    tech_stack text NOT NULL,
+    raw_ai_output text NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS project_evolutions (
+    id text PRIMARY KEY,
+    project_id text NOT NULL,
+    raw_ai_output text NOT NULL,
+    provider_used text NOT NULL,
+    fallback_used numeric NOT NULL DEFAULT false,
+    provider_error text,
+    latency_ms integer NOT NULL DEFAULT 0,
+    created_at datetime NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_evolutions_project_id ON project_evolutions (project_id);
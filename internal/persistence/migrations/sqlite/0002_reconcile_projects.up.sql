@@ -0,0 +1,5 @@
+-- SQLite databases were only ever created from the models schema, so there is
+-- no entities-era description column to fold in; just add the shared index.
+-- The Go prepare step for this version still moves any entities-era
+-- generations, project_dna or project_similarity table to *_legacy first.
+CREATE INDEX IF NOT EXISTS idx_projects_created_at ON projects (created_at);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS project_readiness_reviews (
+    id text PRIMARY KEY,
+    project_id text NOT NULL,
+    verdict text NOT NULL,
+    built_scope text NOT NULL,
+    raw_ai_output text NOT NULL,
+    provider_used text NOT NULL,
+    fallback_used numeric NOT NULL DEFAULT false,
+    provider_error text,
+    latency_ms integer NOT NULL DEFAULT 0,
+    created_at datetime NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_readiness_reviews_project_id ON project_readiness_reviews (project_id);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS project_similarity (
+    id text PRIMARY KEY,
+    project_id text NOT NULL,
+    compared_project_id text NOT NULL,
+    title_score real NOT NULL DEFAULT 0,
+    problem_score real NOT NULL DEFAULT 0,
+    features_score real NOT NULL DEFAULT 0,
+    users_score real NOT NULL DEFAULT 0,
+    tech_score real NOT NULL DEFAULT 0,
+    complexity_score real NOT NULL DEFAULT 0,
+    similarity_score real NOT NULL DEFAULT 0,
+    dominant_dimension text NOT NULL,
+    created_at datetime NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_similarity_project_id ON project_similarity (project_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS project_embeddings (
+    project_id text NOT NULL,
+    model text NOT NULL,
+    dims integer NOT NULL,
+    vector text NOT NULL,
+    created_at datetime NOT NULL,
+    PRIMARY KEY (project_id, model)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_embeddings_model ON project_embeddings (model);
+
+ALTER TABLE project_similarity ADD COLUMN semantic_score real NOT NULL DEFAULT 0;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS project_lsh_buckets (
+    project_id text NOT NULL,
+    bucket text NOT NULL,
+    PRIMARY KEY (project_id, bucket)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_lsh_buckets_bucket ON project_lsh_buckets (bucket);
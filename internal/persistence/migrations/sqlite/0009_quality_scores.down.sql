@@ -0,0 +1,4 @@
+ALTER TABLE projects DROP COLUMN quality_judgement;
+ALTER TABLE projects DROP COLUMN judge_quality_score;
+ALTER TABLE projects DROP COLUMN heuristic_quality_score;
+ALTER TABLE projects DROP COLUMN quality_score;
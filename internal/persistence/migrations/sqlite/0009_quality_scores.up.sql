@@ -0,0 +1,4 @@
+ALTER TABLE projects ADD COLUMN quality_score real;
+ALTER TABLE projects ADD COLUMN heuristic_quality_score real;
+ALTER TABLE projects ADD COLUMN judge_quality_score real;
+ALTER TABLE projects ADD COLUMN quality_judgement text;
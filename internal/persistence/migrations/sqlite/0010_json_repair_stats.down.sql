@@ -0,0 +1 @@
+DROP TABLE IF EXISTS json_repair_stats;
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// JSONRepairStat counts how often model answers needed one JSON repair path.
+type JSONRepairStat struct {
+	Path      string    `gorm:"type:text;primaryKey;column:path"`
+	Count     int64     `gorm:"not null;column:count"`
+	UpdatedAt time.Time `gorm:"not null;column:updated_at"`
+}
+
+func (JSONRepairStat) TableName() string {
+	return "json_repair_stats"
+}
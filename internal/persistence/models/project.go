@@ -26,13 +26,18 @@ type Project struct {
 	Complexity string `gorm:"not null"`
 	Duration   string `gorm:"not null"`
 
-	AIProvider    string  `gorm:"not null"`
+	AIProvider    string  `gorm:"not null;column:ai_provider"`
 	ProviderUsed  string  `gorm:"type:text;not null;column:provider_used"`
 	FallbackUsed  bool    `gorm:"not null;default:false;column:fallback_used"`
 	ProviderError *string `gorm:"type:text;column:provider_error"`
 	LatencyMS     int64   `gorm:"not null;default:0;column:latency_ms"`
 	RetryReason   *string `gorm:"type:text;column:retry_reason"`
 
+	QualityScore          *float64 `gorm:"column:quality_score"`
+	HeuristicQualityScore *float64 `gorm:"column:heuristic_quality_score"`
+	JudgeQualityScore     *float64 `gorm:"column:judge_quality_score"`
+	QualityJudgement      *string  `gorm:"type:jsonb;column:quality_judgement"`
+
 	CreatedAt time.Time `gorm:"not null"`
 }
 
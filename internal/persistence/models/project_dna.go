@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectDNA struct {
+	ProjectID uuid.UUID `gorm:"type:uuid;primaryKey;column:project_id"`
+
+	AppType            string `gorm:"type:text;not null;column:app_type"`
+	PrimaryDomain      string `gorm:"type:text;not null;column:primary_domain"`
+	CoreTechStackJSON  string `gorm:"type:jsonb;not null;column:core_tech_stack"`
+	ArchitecturalStyle string `gorm:"type:text;not null;column:architectural_style"`
+	ComplexityLevel    string `gorm:"type:text;not null;column:complexity_level"`
+	Fingerprint        string `gorm:"type:text;not null;index;column:fingerprint"`
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (ProjectDNA) TableName() string {
+	return "project_dna"
+}
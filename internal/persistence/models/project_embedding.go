@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectEmbedding is one project's vector in one embedding model's space.
+type ProjectEmbedding struct {
+	ProjectID  uuid.UUID `gorm:"type:uuid;primaryKey;column:project_id"`
+	Model      string    `gorm:"type:text;primaryKey;column:model"`
+	Dims       int       `gorm:"not null;column:dims"`
+	VectorJSON string    `gorm:"type:jsonb;not null;column:vector"`
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (ProjectEmbedding) TableName() string {
+	return "project_embeddings"
+}
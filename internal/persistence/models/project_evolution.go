@@ -10,6 +10,7 @@ type ProjectEvolution struct {
 	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
 	ProjectID uuid.UUID `gorm:"type:uuid;not null;index;column:project_id"`
 
+	Contract    string `gorm:"type:text;not null;default:project_evolution;column:contract"`
 	RawAIOutput string `gorm:"type:jsonb;not null;column:raw_ai_output"`
 
 	ProviderUsed  string  `gorm:"type:text;not null;column:provider_used"`
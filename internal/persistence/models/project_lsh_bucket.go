@@ -0,0 +1,13 @@
+package models
+
+import "github.com/google/uuid"
+
+// ProjectLSHBucket places a project in one MinHash LSH bucket.
+type ProjectLSHBucket struct {
+	ProjectID uuid.UUID `gorm:"type:uuid;primaryKey;column:project_id"`
+	Bucket    string    `gorm:"type:text;primaryKey;column:bucket"`
+}
+
+func (ProjectLSHBucket) TableName() string {
+	return "project_lsh_buckets"
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectScopeVersion struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ProjectID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_project_scope_versions_project_version;column:project_id"`
+	Version     int        `gorm:"not null;uniqueIndex:idx_project_scope_versions_project_version"`
+	EvolutionID *uuid.UUID `gorm:"type:uuid;column:evolution_id"`
+
+	MustHaveJSON   string `gorm:"type:jsonb;not null;column:must_have"`
+	OutOfScopeJSON string `gorm:"type:jsonb;not null;column:out_of_scope"`
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (ProjectScopeVersion) TableName() string {
+	return "project_scope_versions"
+}
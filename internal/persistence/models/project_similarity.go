@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectSimilarity struct {
+	ID                uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ProjectID         uuid.UUID `gorm:"type:uuid;not null;index;column:project_id"`
+	ComparedProjectID uuid.UUID `gorm:"type:uuid;not null;column:compared_project_id"`
+
+	TitleScore        float64 `gorm:"not null;default:0;column:title_score"`
+	ProblemScore      float64 `gorm:"not null;default:0;column:problem_score"`
+	FeaturesScore     float64 `gorm:"not null;default:0;column:features_score"`
+	UsersScore        float64 `gorm:"not null;default:0;column:users_score"`
+	TechScore         float64 `gorm:"not null;default:0;column:tech_score"`
+	ComplexityScore   float64 `gorm:"not null;default:0;column:complexity_score"`
+	SemanticScore     float64 `gorm:"not null;default:0;column:semantic_score"`
+	SimilarityScore   float64 `gorm:"not null;default:0;column:similarity_score"`
+	DominantDimension string  `gorm:"type:text;not null;column:dominant_dimension"`
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (ProjectSimilarity) TableName() string {
+	return "project_similarity"
+}
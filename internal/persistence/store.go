@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"quibit/internal/persistence/models"
+)
+
+var (
+	ErrDuplicateDNA = errors.New("duplicate dna hash")
+	ErrNotFound     = errors.New("not found")
+)
+
+// ProjectRecord is everything written when a project is generated. SaveProject
+// stores it in one transaction, so a saved project always has its DNA and LSH
+// buckets and, when it was compared against the library, its similarity
+// breakdown and embedding.
+type ProjectRecord struct {
+	Project    models.Project
+	Features   []models.ProjectFeature
+	Meta       models.ProjectMeta
+	DNA        models.ProjectDNA
+	Similarity *models.ProjectSimilarity
+	Embedding  *models.ProjectEmbedding
+	LSHBuckets []string
+}
+
+type Store interface {
+	SaveProject(ctx context.Context, record ProjectRecord) error
+	GetProject(ctx context.Context, id uuid.UUID) (models.Project, error)
+	ListRecentProjects(ctx context.Context, limit int) ([]models.Project, error)
+	ListProjectsByID(ctx context.Context, ids []uuid.UUID) ([]models.Project, error)
+
+	SaveEvolution(ctx context.Context, evolution models.ProjectEvolution) error
+	ListEvolutions(ctx context.Context, projectID uuid.UUID) ([]models.ProjectEvolution, error)
+	SaveEvolutionPhase(ctx context.Context, evolution models.ProjectEvolution, scope models.ProjectScopeVersion) (models.ProjectScopeVersion, error)
+	ListScopeVersions(ctx context.Context, projectID uuid.UUID) ([]models.ProjectScopeVersion, error)
+
+	SaveProjectDNA(ctx context.Context, dna models.ProjectDNA) error
+	GetProjectDNA(ctx context.Context, projectID uuid.UUID) (models.ProjectDNA, error)
+
+	SaveReadinessReview(ctx context.Context, review models.ProjectReadinessReview) error
+	ListReadinessReviews(ctx context.Context, projectID uuid.UUID) ([]models.ProjectReadinessReview, error)
+
+	ListSimilarities(ctx context.Context, projectID uuid.UUID) ([]models.ProjectSimilarity, error)
+
+	SaveEmbeddings(ctx context.Context, embeddings []models.ProjectEmbedding) error
+	ListEmbeddings(ctx context.Context, model string, projectIDs []uuid.UUID) ([]models.ProjectEmbedding, error)
+
+	RebuildLSHIndex(ctx context.Context, buckets map[uuid.UUID][]string) error
+	FindLSHCandidates(ctx context.Context, buckets []string) ([]uuid.UUID, error)
+
+	AddJSONRepairCounts(ctx context.Context, counts map[string]int64) error
+	ListJSONRepairStats(ctx context.Context) ([]models.JSONRepairStat, error)
+
+	Close() error
+}
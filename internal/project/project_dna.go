@@ -74,3 +74,30 @@ func normalizeStringList(in []string) []string {
 	sort.Strings(out)
 	return out
 }
+
+var architecturalStyleKeywords = []struct {
+	style    string
+	keywords []string
+}{
+	{"hexagonal", []string{"hexagonal", "ports and adapters", "ports/adapters"}},
+	{"cqrs", []string{"cqrs", "read model projection"}},
+	{"event-driven", []string{"event-driven", "event driven", "message queue", "pub/sub", "kafka", "nats", "rabbitmq", "async workers"}},
+	{"microservices", []string{"microservice"}},
+	{"serverless", []string{"serverless", "lambda", "cloud functions"}},
+	{"plugin-based", []string{"plugin-based", "plugin architecture", "extensible core"}},
+	{"offline-first", []string{"offline-first", "local-first"}},
+}
+
+// InferArchitecturalStyle picks the first known style mentioned in texts,
+// falling back to a monolith.
+func InferArchitecturalStyle(texts ...string) string {
+	joined := normalizeScalar(strings.Join(texts, " "))
+	for _, s := range architecturalStyleKeywords {
+		for _, k := range s.keywords {
+			if strings.Contains(joined, k) {
+				return s.style
+			}
+		}
+	}
+	return "monolith"
+}
@@ -2,6 +2,7 @@ package project
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -53,6 +54,17 @@ func JaccardSimilarity(a, b Snapshot) float64 {
 	return float64(intersect) / float64(union)
 }
 
+// SnapshotTokens is the token set JaccardSimilarity compares, sorted.
+func SnapshotTokens(s Snapshot) []string {
+	set := tokenSet(s)
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
 var tokenRe = regexp.MustCompile(`[a-z0-9]+`)
 
 func tokenSet(s Snapshot) map[string]struct{} {
@@ -0,0 +1,196 @@
+package quality
+
+import (
+	"fmt"
+	"strings"
+)
+
+// condition is a parsed rule `when` expression over signal names.
+type condition interface {
+	eval(fired map[string]bool) bool
+}
+
+type signalRef string
+
+func (c signalRef) eval(fired map[string]bool) bool { return fired[string(c)] }
+
+type notCondition struct{ c condition }
+
+func (c notCondition) eval(fired map[string]bool) bool { return !c.c.eval(fired) }
+
+type andCondition []condition
+
+func (c andCondition) eval(fired map[string]bool) bool {
+	for _, sub := range c {
+		if !sub.eval(fired) {
+			return false
+		}
+	}
+	return true
+}
+
+type orCondition []condition
+
+func (c orCondition) eval(fired map[string]bool) bool {
+	for _, sub := range c {
+		if sub.eval(fired) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCondition parses expressions such as `crud && !(depth || twist)` and
+// returns the signal names it refers to, in order of appearance.
+func parseCondition(expr string) (condition, []string, error) {
+	p := &conditionParser{tokens: tokenizeCondition(expr)}
+	if len(p.tokens) == 0 {
+		return nil, nil, fmt.Errorf("empty expression")
+	}
+	c, err := p.parseOr()
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return c, p.refs, nil
+}
+
+type conditionParser struct {
+	tokens []string
+	pos    int
+	refs   []string
+}
+
+func (p *conditionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *conditionParser) parseOr() (condition, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	out := orCondition{first}
+	for p.peek() == "||" {
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, next)
+	}
+	if len(out) == 1 {
+		return first, nil
+	}
+	return out, nil
+}
+
+func (p *conditionParser) parseAnd() (condition, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	out := andCondition{first}
+	for p.peek() == "&&" {
+		p.pos++
+		next, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, next)
+	}
+	if len(out) == 1 {
+		return first, nil
+	}
+	return out, nil
+}
+
+func (p *conditionParser) parseUnary() (condition, error) {
+	switch tok := p.peek(); tok {
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case "!":
+		p.pos++
+		c, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notCondition{c}, nil
+	case "(":
+		p.pos++
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return c, nil
+	default:
+		if !isConditionIdent(tok) {
+			return nil, fmt.Errorf("unexpected %q", tok)
+		}
+		p.pos++
+		seen := false
+		for _, r := range p.refs {
+			if r == tok {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			p.refs = append(p.refs, tok)
+		}
+		return signalRef(tok), nil
+	}
+}
+
+func tokenizeCondition(expr string) []string {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case strings.HasPrefix(expr[i:], "&&"), strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, expr[i:i+2])
+			i += 2
+		case c == '!' || c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			j := i
+			for j < len(expr) && isConditionIdentByte(expr[j]) {
+				j++
+			}
+			if j == i {
+				j = i + 1
+			}
+			tokens = append(tokens, expr[i:j])
+			i = j
+		}
+	}
+	return tokens
+}
+
+func isConditionIdent(tok string) bool {
+	if tok == "" {
+		return false
+	}
+	for i := 0; i < len(tok); i++ {
+		if !isConditionIdentByte(tok[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isConditionIdentByte(c byte) bool {
+	return c == '_' || c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,65 @@
+package quality
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConditionPrecedence(t *testing.T) {
+	cond, refs, err := parseCondition("a && !(b || c)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(refs, want) {
+		t.Errorf("refs = %v, want %v", refs, want)
+	}
+	for _, a := range []bool{false, true} {
+		for _, b := range []bool{false, true} {
+			for _, c := range []bool{false, true} {
+				fired := map[string]bool{"a": a, "b": b, "c": c}
+				if got, want := cond.eval(fired), a && !(b || c); got != want {
+					t.Errorf("eval(%v) = %v, want %v", fired, got, want)
+				}
+			}
+		}
+	}
+
+	// && binds tighter than ||, and ! applies to the nearest operand.
+	cond, _, err = parseCondition("!a || b && c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		fired map[string]bool
+		want  bool
+	}{
+		{map[string]bool{}, true},
+		{map[string]bool{"a": true}, false},
+		{map[string]bool{"a": true, "b": true}, false},
+		{map[string]bool{"a": true, "b": true, "c": true}, true},
+	} {
+		if got := cond.eval(tt.fired); got != tt.want {
+			t.Errorf("!a || b && c with %v = %v, want %v", tt.fired, got, tt.want)
+		}
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", "empty expression"},
+		{"a && !(b || c", "missing )"},
+		{"a &&", "unexpected end of expression"},
+		{"a b", `unexpected "b"`},
+		{"a & b", `unexpected "&"`},
+		{"(a))", `unexpected ")"`},
+	}
+	for _, tt := range tests {
+		if _, _, err := parseCondition(tt.expr); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseCondition(%q) error = %v, want %q", tt.expr, err, tt.want)
+		}
+	}
+}
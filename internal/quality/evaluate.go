@@ -0,0 +1,146 @@
+package quality
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Document is the text of an idea split into the parts signals match against.
+type Document struct {
+	All         string
+	Interesting string
+	MustHave    []string
+	NiceToHave  []string
+	OutOfScope  []string
+}
+
+type Report struct {
+	Decision Decision
+	HardFail bool
+	Score    float64
+	// Reasons are the reasons of every fired rule sharing the deciding
+	// rule's decision, highest weight first.
+	Reasons []string
+	Rules   []RuleResult
+}
+
+type RuleResult struct {
+	ID       string
+	Decision Decision
+	HardFail bool
+	Weight   float64
+	Reason   string
+	Fired    bool
+	Signals  []SignalResult
+}
+
+type SignalResult struct {
+	Name    string
+	Fired   bool
+	Matches []string
+}
+
+func (r Report) OK() bool {
+	return !r.HardFail && r.Decision == Accept
+}
+
+func (r Report) Fired() []RuleResult {
+	var out []RuleResult
+	for _, rr := range r.Rules {
+		if rr.Fired {
+			out = append(out, rr)
+		}
+	}
+	return out
+}
+
+func (rs *Ruleset) Evaluate(doc Document) Report {
+	signals := make(map[string]SignalResult, len(rs.signals))
+	fired := make(map[string]bool, len(rs.signals))
+	for _, name := range rs.names {
+		res := rs.signals[name].match(doc)
+		signals[name] = res
+		fired[name] = res.Fired
+	}
+
+	report := Report{Decision: Accept, Rules: make([]RuleResult, 0, len(rs.rules))}
+	total, failed := 0.0, 0.0
+	var hits []RuleResult
+	for _, r := range rs.rules {
+		res := RuleResult{
+			ID:       r.ID,
+			Decision: r.Decision,
+			HardFail: r.HardFail,
+			Weight:   r.Weight,
+			Reason:   r.Reason,
+			Fired:    r.cond.eval(fired),
+		}
+		for _, name := range r.signals {
+			res.Signals = append(res.Signals, signals[name])
+		}
+		report.Rules = append(report.Rules, res)
+		total += r.Weight
+		if res.Fired {
+			failed += r.Weight
+			hits = append(hits, res)
+		}
+	}
+	if total > 0 {
+		report.Score = 1 - failed/total
+	}
+	if len(hits) == 0 {
+		return report
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Weight > hits[j].Weight })
+	report.Decision = hits[0].Decision
+	report.HardFail = hits[0].HardFail
+	for _, h := range hits {
+		if h.Decision == report.Decision {
+			report.Reasons = append(report.Reasons, h.Reason)
+		}
+	}
+	return report
+}
+
+func (s *signal) match(doc Document) SignalResult {
+	res := SignalResult{Name: s.name}
+	var text string
+	items := -1
+	switch s.text {
+	case TextAll:
+		text = doc.All
+	case TextInteresting:
+		text = doc.Interesting
+	case TextMustHave:
+		text, items = strings.Join(doc.MustHave, " | "), len(doc.MustHave)
+	case TextNiceToHave:
+		text, items = strings.Join(doc.NiceToHave, " | "), len(doc.NiceToHave)
+	case TextOutOfScope:
+		text, items = strings.Join(doc.OutOfScope, " | "), len(doc.OutOfScope)
+	}
+	text = strings.ToLower(text)
+
+	if items >= 0 {
+		if items < s.minItems || (s.maxItems >= 0 && items > s.maxItems) {
+			return res
+		}
+	}
+	if !s.matching {
+		res.Fired = true
+		return res
+	}
+	for _, n := range s.needles {
+		if strings.Contains(text, n) {
+			res.Matches = append(res.Matches, strings.TrimSpace(n))
+		}
+	}
+	for _, re := range s.patterns {
+		if m := re.FindString(text); m != "" && !slices.Contains(res.Matches, m) {
+			res.Matches = append(res.Matches, m)
+		}
+	}
+	res.Fired = len(res.Matches) >= s.minMatches
+	return res
+}
@@ -0,0 +1,234 @@
+package quality
+
+import (
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, src string) *Ruleset {
+	t.Helper()
+	rs, err := Parse([]byte(src), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs
+}
+
+func firedRules(r Report) []string {
+	var ids []string
+	for _, rr := range r.Fired() {
+		ids = append(ids, rr.ID)
+	}
+	return ids
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"unknown signal", `
+version: 1
+signals:
+  a: {text: all, needles: {en: [x]}}
+rules:
+  - {id: r, when: a && missing, decision: REFINE}
+`, `rule r: unknown signal "missing"`},
+		{"missing paren", `
+version: 1
+signals:
+  a: {text: all, needles: {en: [x]}}
+rules:
+  - {id: r, when: "!(a", decision: REFINE}
+`, "rule r: when: missing )"},
+		{"items on text", `
+version: 1
+signals:
+  a: {text: all, min_items: 2, needles: {en: [x]}}
+rules:
+  - {id: r, when: a, decision: REFINE}
+`, "min_items/max_items only apply to list texts"},
+		{"accept decision", `
+version: 1
+signals:
+  a: {text: all, needles: {en: [x]}}
+rules:
+  - {id: r, when: a, decision: ACCEPT}
+`, "decision must be REGENERATE, REFINE or PIVOT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.src), "test"); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestListSignalItemBounds(t *testing.T) {
+	rs := mustParse(t, `
+version: 1
+signals:
+  sized:
+    text: must_have
+    min_items: 2
+    max_items: 3
+  placeholder:
+    text: out_of_scope
+    max_items: 2
+    needles: {en: [tbd]}
+rules:
+  - {id: sized, when: sized, decision: REFINE}
+  - {id: placeholder, when: placeholder, decision: REFINE}
+`)
+	tests := []struct {
+		must []string
+		out  []string
+		want string
+	}{
+		{[]string{"a"}, []string{"x"}, ""},
+		{[]string{"a", "b"}, []string{"x"}, "sized"},
+		{[]string{"a", "b", "c"}, []string{"tbd"}, "sized placeholder"},
+		{[]string{"a", "b", "c", "d"}, []string{"x", "tbd"}, "placeholder"},
+		{nil, []string{"x", "y", "tbd"}, ""},
+	}
+	for _, tt := range tests {
+		r := rs.Evaluate(Document{MustHave: tt.must, OutOfScope: tt.out})
+		if got := strings.Join(firedRules(r), " "); got != tt.want {
+			t.Errorf("must_have=%v out_of_scope=%v fired %q, want %q", tt.must, tt.out, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateWeightTieBreak(t *testing.T) {
+	const signals = `
+version: 1
+signals:
+  a: {text: all, needles: {en: [alpha]}}
+  b: {text: all, needles: {en: [beta]}}
+rules:
+`
+	doc := Document{All: "Alpha and Beta"}
+
+	r := mustParse(t, signals+`
+  - {id: first, when: a, decision: REFINE, weight: 0.5, reason: first}
+  - {id: second, when: b, decision: PIVOT, weight: 0.5, reason: second}
+  - {id: both, when: a && b, decision: REFINE, weight: 0.2, reason: both}
+`).Evaluate(doc)
+	if r.Decision != Refine || strings.Join(r.Reasons, ",") != "first,both" {
+		t.Errorf("tie: decision %s reasons %v, want REFINE [first both]", r.Decision, r.Reasons)
+	}
+	if r.Score != 0 {
+		t.Errorf("score = %v, want 0 with every rule fired", r.Score)
+	}
+
+	r = mustParse(t, signals+`
+  - {id: second, when: b, decision: PIVOT, weight: 0.5, reason: second}
+  - {id: first, when: a, decision: REFINE, weight: 0.5, reason: first}
+  - {id: heavy, when: "!a", decision: REGENERATE, hard_fail: true, weight: 3, reason: heavy}
+`).Evaluate(doc)
+	if r.Decision != Pivot || r.HardFail || strings.Join(r.Reasons, ",") != "second" {
+		t.Errorf("reordered tie: decision %s hard_fail %v reasons %v, want PIVOT [second]", r.Decision, r.HardFail, r.Reasons)
+	}
+	if r.Score != 0.75 {
+		t.Errorf("score = %v, want 0.75", r.Score)
+	}
+}
+
+// legacyGoodDocument passes every check the hard-coded gate made before the
+// rules moved to YAML.
+func legacyGoodDocument() Document {
+	return Document{
+		All: "Ledger sync: a tamper-evident append-only log with deterministic replay. " +
+			"The architecture uses an outbox and idempotency keys for consistency; " +
+			"we accept the latency vs durability trade-off and keep pii out of the log.",
+		Interesting: "tamper-evident history you can replay",
+		MustHave:    []string{"append entries", "verify the hash chain", "replay to a point in time"},
+		NiceToHave:  []string{"parquet export", "web viewer", "signed snapshots"},
+		OutOfScope:  []string{"multi-user sharing", "mobile app"},
+	}
+}
+
+// TestDefaultRulesMatchLegacyGate pins the embedded ruleset to the decisions
+// and reasons of the old looksCliche, looksLikeCRUD and scopeRealismCheck
+// checks.
+func TestDefaultRulesMatchLegacyGate(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      func(d *Document)
+		decision Decision
+		hardFail bool
+		reason   string
+	}{
+		{"accepted", func(d *Document) {}, Accept, false, ""},
+		{"cliche", func(d *Document) {
+			*d = Document{
+				All:         "A todo list app with reminders and a clean ui",
+				Interesting: "a simple todo list",
+				MustHave:    []string{"add tasks", "reminders"},
+				NiceToHave:  []string{"themes"},
+				OutOfScope:  []string{"sync"},
+			}
+		}, Regenerate, true, "anti-generic FAIL: cliché category without an extreme technical twist"},
+		{"cliche with twist", func(d *Document) {
+			d.All = "A todo list with crdt offline-first sync. The architecture makes a latency vs battery trade-off."
+			d.Interesting = "local-first todo list"
+		}, Accept, false, ""},
+		{"crud", func(d *Document) {
+			*d = Document{
+				All:         "An admin dashboard to manage users with login and a profile page",
+				Interesting: "manage your team in one place",
+				MustHave:    []string{"user list", "profile page"},
+				NiceToHave:  []string{"dark mode"},
+				OutOfScope:  []string{"billing"},
+			}
+		}, Regenerate, true, "anti-generic FAIL: CRUD-y scope with no depth/constraints/twist"},
+		{"crud with depth", func(d *Document) {
+			d.All = "An admin dashboard with an audit log and idempotency keys. " +
+				"The architecture trades latency vs cost."
+			d.Interesting = "tamper-evident audit log for admin actions"
+		}, Accept, false, ""},
+		{"must-have too large", func(d *Document) {
+			d.MustHave = []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+		}, Refine, false, "scope/realism FAIL: MVP must-have list is too large (>=8) for a solo MVP"},
+		{"seven must-haves", func(d *Document) {
+			d.MustHave = []string{"a", "b", "c", "d", "e", "f", "g"}
+		}, Accept, false, ""},
+		{"three big rocks", func(d *Document) {
+			d.MustHave = []string{"payments", "messaging", "recommendation feed"}
+		}, Refine, false, "scope/realism FAIL: too many big-scope features packed into MVP (payments/chat/recommendations/multi-tenant/etc.)"},
+		{"two big rocks", func(d *Document) {
+			d.MustHave = []string{"payments", "messaging", "search"}
+		}, Accept, false, ""},
+		{"empty nice-to-have", func(d *Document) {
+			d.NiceToHave = nil
+		}, Refine, false, "scope/realism FAIL: scope lists are too vague (nice-to-have/out-of-scope read like placeholders)"},
+		{"placeholder out-of-scope", func(d *Document) {
+			d.OutOfScope = []string{"more features", "tbd"}
+		}, Refine, false, "scope/realism FAIL: scope lists are too vague (nice-to-have/out-of-scope read like placeholders)"},
+		{"long out-of-scope with placeholder", func(d *Document) {
+			d.OutOfScope = []string{"sharing", "mobile app", "improvements"}
+		}, Accept, false, ""},
+	}
+	rs := Default()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := legacyGoodDocument()
+			tt.doc(&doc)
+			r := rs.Evaluate(doc)
+			if r.Decision != tt.decision || r.HardFail != tt.hardFail {
+				t.Fatalf("decision %s hard_fail %v, want %s %v (fired %v)", r.Decision, r.HardFail, tt.decision, tt.hardFail, firedRules(r))
+			}
+			if tt.reason == "" {
+				if len(r.Reasons) != 0 {
+					t.Errorf("reasons = %v, want none", r.Reasons)
+				}
+				return
+			}
+			if len(r.Reasons) == 0 || r.Reasons[0] != tt.reason {
+				t.Errorf("reasons = %v, want %q first", r.Reasons, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package quality
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Decision string
+
+const (
+	Accept     Decision = "ACCEPT"
+	Refine     Decision = "REFINE"
+	Pivot      Decision = "PIVOT"
+	Regenerate Decision = "REGENERATE"
+)
+
+// Texts a signal can be matched against.
+const (
+	TextAll         = "all"
+	TextInteresting = "interesting"
+	TextMustHave    = "must_have"
+	TextNiceToHave  = "nice_to_have"
+	TextOutOfScope  = "out_of_scope"
+)
+
+//go:embed default_rules.yaml
+var defaultRules []byte
+
+// Spec is a ruleset as written in YAML or JSON.
+type Spec struct {
+	Version   int               `yaml:"version"`
+	Languages []string          `yaml:"languages"`
+	Signals   map[string]Signal `yaml:"signals"`
+	Rules     []Rule            `yaml:"rules"`
+}
+
+type Signal struct {
+	Text       string              `yaml:"text"`
+	Needles    map[string][]string `yaml:"needles"`
+	Patterns   []string            `yaml:"patterns"`
+	MinMatches int                 `yaml:"min_matches"`
+	MinItems   int                 `yaml:"min_items"`
+	MaxItems   *int                `yaml:"max_items"`
+}
+
+type Rule struct {
+	ID       string   `yaml:"id"`
+	When     string   `yaml:"when"`
+	Decision Decision `yaml:"decision"`
+	HardFail bool     `yaml:"hard_fail"`
+	Weight   float64  `yaml:"weight"`
+	Reason   string   `yaml:"reason"`
+}
+
+// Ruleset is a validated Spec ready to evaluate ideas.
+type Ruleset struct {
+	source  string
+	signals map[string]*signal
+	names   []string
+	rules   []*rule
+}
+
+type signal struct {
+	name       string
+	text       string
+	needles    []string
+	patterns   []*regexp.Regexp
+	matching   bool
+	minMatches int
+	minItems   int
+	maxItems   int
+}
+
+type rule struct {
+	Rule
+	cond    condition
+	signals []string
+}
+
+// Default is the embedded ruleset.
+func Default() *Ruleset {
+	rs, err := Parse(defaultRules, "default")
+	if err != nil {
+		panic(fmt.Sprintf("quality: embedded ruleset: %v", err))
+	}
+	return rs
+}
+
+// Load reads a ruleset from path, or returns the embedded default when path
+// is empty. YAML and JSON are both accepted.
+func Load(path string) (*Ruleset, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return Default(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("quality: read ruleset: %w", err)
+	}
+	return Parse(data, path)
+}
+
+func Parse(data []byte, source string) (*Ruleset, error) {
+	var spec Spec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("quality: parse %s: %w", source, err)
+	}
+	rs, err := compile(spec)
+	if err != nil {
+		return nil, fmt.Errorf("quality: %s: %w", source, err)
+	}
+	rs.source = source
+	return rs, nil
+}
+
+// Source is the file the ruleset came from, or "default".
+func (rs *Ruleset) Source() string { return rs.source }
+
+func compile(spec Spec) (*Ruleset, error) {
+	if spec.Version != 1 {
+		return nil, fmt.Errorf("unsupported version %d (want 1)", spec.Version)
+	}
+	if len(spec.Rules) == 0 {
+		return nil, fmt.Errorf("no rules")
+	}
+	langs := map[string]bool{}
+	for _, l := range spec.Languages {
+		langs[strings.ToLower(strings.TrimSpace(l))] = true
+	}
+
+	rs := &Ruleset{signals: make(map[string]*signal, len(spec.Signals))}
+	for name, s := range spec.Signals {
+		compiled, err := compileSignal(name, s, langs)
+		if err != nil {
+			return nil, err
+		}
+		rs.signals[name] = compiled
+		rs.names = append(rs.names, name)
+	}
+	sort.Strings(rs.names)
+
+	seen := map[string]bool{}
+	for i, r := range spec.Rules {
+		if strings.TrimSpace(r.ID) == "" {
+			return nil, fmt.Errorf("rule %d: id is required", i+1)
+		}
+		if seen[r.ID] {
+			return nil, fmt.Errorf("rule %s: duplicate id", r.ID)
+		}
+		seen[r.ID] = true
+		switch r.Decision {
+		case Regenerate, Refine, Pivot:
+		default:
+			return nil, fmt.Errorf("rule %s: decision must be REGENERATE, REFINE or PIVOT, got %q", r.ID, r.Decision)
+		}
+		if r.Weight < 0 {
+			return nil, fmt.Errorf("rule %s: weight must not be negative", r.ID)
+		}
+		if r.Weight == 0 {
+			r.Weight = 1
+		}
+		cond, refs, err := parseCondition(r.When)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: when: %w", r.ID, err)
+		}
+		for _, ref := range refs {
+			if _, ok := rs.signals[ref]; !ok {
+				return nil, fmt.Errorf("rule %s: unknown signal %q", r.ID, ref)
+			}
+		}
+		if strings.TrimSpace(r.Reason) == "" {
+			r.Reason = r.ID
+		}
+		rs.rules = append(rs.rules, &rule{Rule: r, cond: cond, signals: refs})
+	}
+	return rs, nil
+}
+
+func compileSignal(name string, s Signal, langs map[string]bool) (*signal, error) {
+	out := &signal{name: name, text: s.Text, minMatches: s.MinMatches, minItems: s.MinItems, maxItems: -1}
+	list := false
+	switch s.Text {
+	case TextAll, TextInteresting:
+	case TextMustHave, TextNiceToHave, TextOutOfScope:
+		list = true
+	default:
+		return nil, fmt.Errorf("signal %s: unknown text %q", name, s.Text)
+	}
+	if s.MaxItems != nil {
+		out.maxItems = *s.MaxItems
+	}
+	if !list && (s.MinItems != 0 || s.MaxItems != nil) {
+		return nil, fmt.Errorf("signal %s: min_items/max_items only apply to list texts", name)
+	}
+	if s.MinMatches < 0 || s.MinItems < 0 || out.maxItems < -1 {
+		return nil, fmt.Errorf("signal %s: counts must not be negative", name)
+	}
+	if out.minMatches == 0 {
+		out.minMatches = 1
+	}
+
+	langNames := make([]string, 0, len(s.Needles))
+	for lang := range s.Needles {
+		langNames = append(langNames, lang)
+	}
+	sort.Strings(langNames)
+	for _, lang := range langNames {
+		if len(langs) > 0 && !langs[strings.ToLower(lang)] {
+			continue
+		}
+		for _, n := range s.Needles[lang] {
+			if n = strings.ToLower(n); strings.TrimSpace(n) != "" {
+				out.needles = append(out.needles, n)
+			}
+		}
+	}
+	for _, p := range s.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("signal %s: pattern %q: %w", name, p, err)
+		}
+		out.patterns = append(out.patterns, re)
+	}
+	out.matching = len(s.Needles) > 0 || len(out.patterns) > 0
+	if !out.matching && !list {
+		return nil, fmt.Errorf("signal %s: needs needles or patterns", name)
+	}
+	return out, nil
+}
@@ -0,0 +1,69 @@
+package similarity
+
+import "sort"
+
+// Cluster groups items by average-linkage agglomerative clustering. scores is
+// the symmetric n×n similarity matrix of the items and is not modified;
+// clusters keep merging while the average similarity between the closest pair
+// is at least threshold. Clusters come back largest first, members in
+// ascending order.
+func Cluster(scores [][]float64, threshold float64) [][]int {
+	n := len(scores)
+	clusters := make([][]int, n)
+	for i := range clusters {
+		clusters[i] = []int{i}
+	}
+	// Merging rewrites rows with linkage averages, so work on a copy.
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = append([]float64(nil), scores[i]...)
+	}
+	alive := make([]bool, n)
+	for i := range alive {
+		alive[i] = true
+	}
+
+	for {
+		bi, bj, best := -1, -1, threshold
+		for i := 0; i < n; i++ {
+			if !alive[i] {
+				continue
+			}
+			for j := i + 1; j < n; j++ {
+				if alive[j] && matrix[i][j] >= best {
+					bi, bj, best = i, j, matrix[i][j]
+				}
+			}
+		}
+		if bi < 0 {
+			break
+		}
+
+		ni, nj := float64(len(clusters[bi])), float64(len(clusters[bj]))
+		for k := 0; k < n; k++ {
+			if !alive[k] || k == bi || k == bj {
+				continue
+			}
+			v := (matrix[bi][k]*ni + matrix[bj][k]*nj) / (ni + nj)
+			matrix[bi][k], matrix[k][bi] = v, v
+		}
+		clusters[bi] = append(clusters[bi], clusters[bj]...)
+		clusters[bj] = nil
+		alive[bj] = false
+	}
+
+	out := make([][]int, 0, n)
+	for i := range clusters {
+		if alive[i] {
+			sort.Ints(clusters[i])
+			out = append(out, clusters[i])
+		}
+	}
+	sort.SliceStable(out, func(a, b int) bool {
+		if len(out[a]) != len(out[b]) {
+			return len(out[a]) > len(out[b])
+		}
+		return out[a][0] < out[b][0]
+	})
+	return out
+}
@@ -0,0 +1,27 @@
+package similarity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCluster(t *testing.T) {
+	scores := [][]float64{
+		{0, 0.9, 0.1, 0.2},
+		{0.9, 0, 0.2, 0.1},
+		{0.1, 0.2, 0, 0.3},
+		{0.2, 0.1, 0.3, 0},
+	}
+	before := make([][]float64, len(scores))
+	for i := range scores {
+		before[i] = append([]float64(nil), scores[i]...)
+	}
+
+	got := Cluster(scores, 0.5)
+	if want := [][]int{{0, 1}, {2}, {3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Cluster = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(scores, before) {
+		t.Error("Cluster modified the score matrix")
+	}
+}
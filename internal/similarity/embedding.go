@@ -0,0 +1,57 @@
+package similarity
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"quibit/internal/domain"
+)
+
+// Embedder turns project text into vectors. Vectors are only comparable when
+// they come from the same Model.
+type Embedder interface {
+	Model() string
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// EmbeddingText is the text a project is embedded from: what it is, the
+// problem it solves, who it is for and what the MVP does. The stack is left
+// out so that shared tooling does not make unrelated ideas look alike.
+func EmbeddingText(p domain.Project) string {
+	parts := []string{p.Title, p.Summary, p.ProblemStatement}
+	parts = append(parts, p.TargetUsers...)
+	parts = append(parts, p.CoreFeatures...)
+	out := make([]string, 0, len(parts))
+	for _, v := range parts {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// Cosine is the cosine similarity of two vectors, clamped to [0, 1]. Vectors
+// of different length score 0.
+func Cosine(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return clamp01(dot / (math.Sqrt(na) * math.Sqrt(nb)))
+}
+
+// Blend mixes the lexical score with the semantic one. weight is the share
+// given to the semantic score.
+func Blend(lexical, semantic, weight float64) float64 {
+	weight = clamp01(weight)
+	return clamp01(lexical*(1-weight) + semantic*weight)
+}
@@ -0,0 +1,105 @@
+package similarity
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const defaultLocalDims = 512
+
+// LocalEmbedder is an offline embedder: word and character-trigram features
+// are hashed into a fixed-size vector with sublinear term frequency. It
+// catches reworded ideas that share stems even when whole words differ.
+type LocalEmbedder struct {
+	Dims int
+}
+
+func NewLocalEmbedder() LocalEmbedder {
+	return LocalEmbedder{Dims: defaultLocalDims}
+}
+
+func (e LocalEmbedder) Model() string {
+	return fmt.Sprintf("local-hash-%d", e.dims())
+}
+
+func (e LocalEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		out = append(out, e.embed(text))
+	}
+	return out, nil
+}
+
+func (e LocalEmbedder) dims() int {
+	if e.Dims > 0 {
+		return e.Dims
+	}
+	return defaultLocalDims
+}
+
+func (e LocalEmbedder) embed(text string) []float32 {
+	counts := map[string]float64{}
+	for _, word := range embeddingWords(text) {
+		counts["w:"+word]++
+		padded := "^" + word + "$"
+		runes := []rune(padded)
+		for i := 0; i+3 <= len(runes); i++ {
+			counts["c:"+string(runes[i:i+3])] += 0.5
+		}
+	}
+
+	dims := e.dims()
+	vec := make([]float32, dims)
+	for feature, n := range counts {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(feature))
+		sum := h.Sum64()
+		weight := 1 + math.Log(n)
+		if sum&(1<<63) != 0 {
+			weight = -weight
+		}
+		vec[sum%uint64(dims)] += float32(weight)
+	}
+
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v) * float64(v)
+	}
+	if norm > 0 {
+		norm = math.Sqrt(norm)
+		for i := range vec {
+			vec[i] = float32(float64(vec[i]) / norm)
+		}
+	}
+	return vec
+}
+
+var embeddingStopwords = map[string]struct{}{
+	"and": {}, "the": {}, "for": {}, "with": {}, "that": {}, "this": {}, "from": {},
+	"into": {}, "their": {}, "they": {}, "are": {}, "can": {}, "app": {}, "users": {},
+}
+
+func embeddingWords(s string) []string {
+	s = strings.ToLower(s)
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	out := words[:0]
+	for _, w := range words {
+		if len(w) < 3 {
+			continue
+		}
+		if _, stop := embeddingStopwords[w]; stop {
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
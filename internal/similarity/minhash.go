@@ -0,0 +1,74 @@
+package similarity
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// LSH parameters: 20 bands of 3 rows put two sets in a shared bucket with
+// probability ~0.97 at Jaccard 0.55 (the default regenerate threshold) and
+// ~0.15 at Jaccard 0.2, so unrelated projects rarely become candidates.
+const (
+	lshBands   = 20
+	lshRows    = 3
+	minHashLen = lshBands * lshRows
+)
+
+// MinHash returns the MinHash signature of a token set. The expected share of
+// equal positions between two signatures is the Jaccard similarity of the sets.
+func MinHash(tokens []string) []uint64 {
+	sig := make([]uint64, minHashLen)
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+	for _, tok := range tokens {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(tok))
+		base := h.Sum64()
+		for i := range sig {
+			if v := splitMix64(base ^ minHashSeeds[i]); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+	return sig
+}
+
+// LSHBuckets splits a signature into bands and names one bucket per band.
+// Sets that share any bucket are candidates for a full comparison. kind keeps
+// signatures built from different token sets apart.
+func LSHBuckets(kind string, sig []uint64) []string {
+	if len(sig) != minHashLen {
+		return nil
+	}
+	buckets := make([]string, 0, lshBands)
+	buf := make([]byte, 8)
+	for b := 0; b < lshBands; b++ {
+		h := fnv.New64a()
+		for _, v := range sig[b*lshRows : (b+1)*lshRows] {
+			binary.LittleEndian.PutUint64(buf, v)
+			_, _ = h.Write(buf)
+		}
+		buckets = append(buckets, fmt.Sprintf("%s:%02d:%016x", kind, b, h.Sum64()))
+	}
+	return buckets
+}
+
+var minHashSeeds = func() []uint64 {
+	seeds := make([]uint64, minHashLen)
+	s := uint64(0x9e3779b97f4a7c15)
+	for i := range seeds {
+		s = splitMix64(s)
+		seeds[i] = s
+	}
+	return seeds
+}()
+
+func splitMix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
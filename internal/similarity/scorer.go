@@ -28,29 +28,38 @@ func Score(a domain.Project, b domain.Project) Breakdown {
 		complexity = 1.0
 	}
 
-	total := 0.0
-	total += title * 0.15
-	total += problem * 0.25
-	total += core * 0.25
-	total += users * 0.15
-	total += tech * 0.10
-	total += complexity * 0.10
-
-	return Breakdown{
+	out := Breakdown{
 		TitleSimilarity:            title,
 		ProblemStatementSimilarity: problem,
 		CoreFeaturesOverlap:        core,
 		TargetUsersOverlap:         users,
 		TechStackOverlap:           tech,
 		ComplexityMatch:            complexity,
-		Total:                      clamp01(total),
 	}
+	out.Total = WeightedTotal(out)
+	return out
+}
+
+// WeightedTotal is the weighted sum of b's dimensions, ignoring b.Total.
+func WeightedTotal(b Breakdown) float64 {
+	total := 0.0
+	total += b.TitleSimilarity * 0.15
+	total += b.ProblemStatementSimilarity * 0.25
+	total += b.CoreFeaturesOverlap * 0.25
+	total += b.TargetUsersOverlap * 0.15
+	total += b.TechStackOverlap * 0.10
+	total += b.ComplexityMatch * 0.10
+	return clamp01(total)
 }
 
 func DominantDimension(b Breakdown) string {
 	bestKey := "title"
 	best := b.TitleSimilarity * 0.15
 
+	if v := b.ProblemStatementSimilarity * 0.25; v > best {
+		best = v
+		bestKey = "problem"
+	}
 	if v := b.CoreFeaturesOverlap * 0.25; v > best {
 		best = v
 		bestKey = "features"
@@ -31,6 +31,19 @@ func LoadThresholdsFromEnv() Thresholds {
 	return t
 }
 
+const DefaultEmbeddingWeight = 0.4
+
+// LoadEmbeddingWeightFromEnv is the share of the semantic score in the
+// blended similarity score (SIMILARITY_EMBEDDING_WEIGHT, 0 disables it).
+func LoadEmbeddingWeightFromEnv() float64 {
+	if v := os.Getenv("SIMILARITY_EMBEDDING_WEIGHT"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 && f <= 1 {
+			return f
+		}
+	}
+	return DefaultEmbeddingWeight
+}
+
 type Category int
 
 const (
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+type CompareRow struct {
+	Label  string
+	Values []string
+}
+
+const (
+	compareMinColumn = 18
+	compareGap       = 3
+)
+
+// CompareColumns renders one column per title with labelled rows. When the
+// terminal is too narrow for side-by-side columns it falls back to stacking.
+func CompareColumns(out io.Writer, titles []string, rows []CompareRow) {
+	if len(titles) == 0 {
+		return
+	}
+	l := LayoutFor(out)
+	pad := leftPad(l.HPad())
+
+	labelWidth := 0
+	for _, r := range rows {
+		if n := utf8.RuneCountInString(r.Label); n > labelWidth {
+			labelWidth = n
+		}
+	}
+	colWidth := (l.ContentWidth() - labelWidth - compareGap*len(titles)) / len(titles)
+	if colWidth < compareMinColumn {
+		compareStacked(out, pad, titles, rows)
+		return
+	}
+
+	header := pad + strings.Repeat(" ", labelWidth)
+	for _, t := range titles {
+		header += strings.Repeat(" ", compareGap) + style(padRight(truncateToWidth(t, colWidth), colWidth), ColorAccent)
+	}
+	fmt.Fprintln(out, header)
+	fmt.Fprintln(out, pad+style(strings.Repeat("─", labelWidth+(compareGap+colWidth)*len(titles)), ColorDivider))
+
+	for _, r := range rows {
+		cells := make([][]string, len(titles))
+		height := 1
+		for i := range titles {
+			v := ""
+			if i < len(r.Values) {
+				v = r.Values[i]
+			}
+			cells[i] = wrapSoft(v, colWidth)
+			if len(cells[i]) > height {
+				height = len(cells[i])
+			}
+		}
+		for line := 0; line < height; line++ {
+			label := ""
+			if line == 0 {
+				label = r.Label
+			}
+			s := pad + style(padRight(label, labelWidth), ColorMuted)
+			for i := range cells {
+				cell := ""
+				if line < len(cells[i]) {
+					cell = cells[i][line]
+				}
+				if i < len(cells)-1 {
+					cell = padRight(cell, colWidth)
+				}
+				s += strings.Repeat(" ", compareGap) + style(cell, ColorBody)
+			}
+			fmt.Fprintln(out, s)
+		}
+		fmt.Fprintln(out, "")
+	}
+}
+
+func compareStacked(out io.Writer, pad string, titles []string, rows []CompareRow) {
+	for i, t := range titles {
+		fmt.Fprintln(out, pad+style(t, ColorAccent))
+		for _, r := range rows {
+			if i >= len(r.Values) {
+				continue
+			}
+			fmt.Fprintln(out, pad+"  "+style(r.Label+":", ColorMuted)+" "+style(r.Values[i], ColorBody))
+		}
+		fmt.Fprintln(out, "")
+	}
+}
+
+func padRight(s string, width int) string {
+	n := utf8.RuneCountInString(s)
+	if n >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-n)
+}
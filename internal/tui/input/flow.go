@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	"quibit/internal/model"
+	"quibit/internal/project"
 	"quibit/internal/techstack"
 	"quibit/internal/tui"
 )
@@ -118,6 +120,84 @@ func CollectUserIdeaProjectInput(in *os.File, out io.Writer) (model.ProjectInput
 	}, nil
 }
 
+// CollectIdeaSpec asks only for what is missing from base.
+func CollectIdeaSpec(in *os.File, out io.Writer, base project.IdeaSpec) (project.IdeaSpec, error) {
+	reader := bufio.NewReader(in)
+	spec := base
+
+	tui.AppHeader(out)
+	tui.Heading(out, "Explore setup")
+	tui.Context(out, "Define the base spec. Candidates vary architecture, scale, data model and interaction.")
+	tui.Divider(out)
+
+	var err error
+	if strings.TrimSpace(spec.AppType) == "" {
+		spec.AppType, err = promptSelectWithCustom(in, out, reader, ApplicationTypePrompt)
+		if err != nil {
+			return project.IdeaSpec{}, err
+		}
+		tui.Divider(out)
+	}
+	if strings.TrimSpace(spec.DomainFocus) == "" {
+		printStepHeader(out, "Domain Focus", "Which domain should the project live in? (e.g. field inspections, logistics)", "")
+		spec.DomainFocus, err = promptWithDefault(reader, out, "Input", "")
+		if err != nil {
+			return project.IdeaSpec{}, err
+		}
+		tui.Divider(out)
+	}
+	if strings.TrimSpace(spec.CoreProblem) == "" {
+		printStepHeader(out, "Core Problem", "Describe the problem every candidate must solve.", "")
+		spec.CoreProblem, err = promptWithDefault(reader, out, "Input", "")
+		if err != nil {
+			return project.IdeaSpec{}, err
+		}
+		tui.Divider(out)
+	}
+	if strings.TrimSpace(spec.ComplexityLevel) == "" {
+		spec.ComplexityLevel, err = promptSelectWithCustom(in, out, reader, ComplexityPrompt)
+		if err != nil {
+			return project.IdeaSpec{}, err
+		}
+		tui.Divider(out)
+	}
+	return spec, nil
+}
+
+// CollectBuiltScope asks which MVP items are actually built. Numbers pick
+// items from mvp; anything else is kept as free text.
+func CollectBuiltScope(in *os.File, out io.Writer, mvp []string) ([]string, error) {
+	reader := bufio.NewReader(in)
+
+	printStepHeader(out, "What have you built?", "Enter MVP item numbers and/or describe what is done, separated by commas.", "")
+	for i, item := range mvp {
+		fmt.Fprintf(out, "%d. %s\n", i+1, item)
+	}
+
+	line, err := promptWithDefault(reader, out, "Input", "")
+	if err != nil {
+		return nil, err
+	}
+
+	built := []string{}
+	seen := map[string]bool{}
+	for _, part := range parseList(line) {
+		item := part
+		if n, err := strconv.Atoi(part); err == nil {
+			if n < 1 || n > len(mvp) {
+				return nil, fmt.Errorf("built scope: no MVP item #%d", n)
+			}
+			item = mvp[n-1]
+		}
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+		built = append(built, item)
+	}
+	return built, nil
+}
+
 func collectTechStackAndDatabase(in *os.File, out io.Writer, reader *bufio.Reader, appType string) ([]string, []string, error) {
 	appType = strings.TrimSpace(appType)
 
@@ -354,7 +434,9 @@ func promptWithDefault(reader *bufio.Reader, out io.Writer, label string, defaul
 	}
 	tui.Divider(out)
 	fmt.Fprint(out, tui.PromptPrefix(out))
+	done := tui.AwaitInput()
 	line, err := reader.ReadString('\n')
+	done()
 	if err != nil {
 		return "", err
 	}
@@ -62,6 +62,9 @@ func Transition(ctx context.Context, out io.Writer) {
 	}
 }
 
+// More dynamic spinner frames
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
 type Spinner struct {
 	out     io.Writer
 	message string
@@ -94,8 +97,7 @@ func StartSpinner(ctx context.Context, out io.Writer, message string) *Spinner {
 func (s *Spinner) loop(ctx context.Context) {
 	defer close(s.doneCh)
 
-	// More dynamic spinner frames
-	frames := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	frames := spinnerFrames
 	ticker := time.NewTicker(80 * time.Millisecond)
 	defer ticker.Stop()
 
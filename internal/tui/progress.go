@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+type TaskState int
+
+const (
+	TaskPending TaskState = iota
+	TaskRunning
+	TaskDone
+	TaskFailed
+)
+
+type progressTask struct {
+	label  string
+	state  TaskState
+	detail string
+}
+
+// Progress is a multi-line Spinner: one line per task, repainted in place.
+// Without a terminal only finished tasks are printed, one line each.
+type Progress struct {
+	out      io.Writer
+	tasks    []progressTask
+	animated bool
+	painted  int
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+
+	mu sync.Mutex
+}
+
+func StartProgress(ctx context.Context, out io.Writer, labels []string) *Progress {
+	p := &Progress{
+		out:      out,
+		tasks:    make([]progressTask, len(labels)),
+		animated: motionAllowed(out),
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+	for i, label := range labels {
+		p.tasks[i].label = strings.TrimSpace(label)
+	}
+	if !p.animated {
+		close(p.doneCh)
+		return p
+	}
+	go p.loop(ctx)
+	return p
+}
+
+func (p *Progress) Set(i int, state TaskState, detail string) {
+	if p == nil || i < 0 || i >= len(p.tasks) {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.tasks[i].state = state
+	p.tasks[i].detail = strings.TrimSpace(detail)
+	if !p.animated && (state == TaskDone || state == TaskFailed) {
+		fmt.Fprintln(p.out, p.line(i, ""))
+	}
+}
+
+func (p *Progress) Stop() {
+	if p == nil {
+		return
+	}
+	p.stopOnce.Do(func() { close(p.stopCh) })
+	<-p.doneCh
+}
+
+func (p *Progress) loop(ctx context.Context) {
+	defer close(p.doneCh)
+
+	ticker := time.NewTicker(80 * time.Millisecond)
+	defer ticker.Stop()
+
+	i := 0
+	p.paint(spinnerFrames[0])
+	for {
+		select {
+		case <-ctx.Done():
+			p.paint("")
+			return
+		case <-p.stopCh:
+			p.paint("")
+			return
+		case <-ticker.C:
+			i++
+			p.paint(spinnerFrames[i%len(spinnerFrames)])
+		}
+	}
+}
+
+func (p *Progress) paint(frame string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	moveCursorUp(p.out, p.painted)
+	for i := range p.tasks {
+		fmt.Fprintf(p.out, "\r\033[K%s\n", p.line(i, frame))
+	}
+	p.painted = len(p.tasks)
+}
+
+func (p *Progress) line(i int, frame string) string {
+	l := LayoutFor(p.out)
+	t := p.tasks[i]
+
+	var mark string
+	switch t.state {
+	case TaskDone:
+		mark = style("✓", ColorNeonGreen)
+	case TaskFailed:
+		mark = style("✗", ColorErrorTitle)
+	case TaskRunning:
+		if frame == "" {
+			frame = "…"
+		}
+		mark = style(frame, ColorNeonBlue)
+	default:
+		mark = style("·", ColorMuted)
+	}
+
+	text := t.label
+	if t.detail != "" {
+		text += " — " + t.detail
+	}
+	text = truncateToWidth(sanitizeOneLine(text), l.ContentWidth()-2)
+	color := ColorStatus
+	if t.state == TaskFailed {
+		color = ColorErrorDetail
+	}
+	return leftPad(l.HPad()) + mark + " " + style(text, color)
+}
@@ -1,8 +1,26 @@
 package tui
 
-import "io"
+import (
+	"io"
+	"sync/atomic"
+)
+
+var pendingInput atomic.Int32
 
 func PromptPrefix(out io.Writer) string {
 	l := LayoutFor(out)
 	return leftPad(l.HPad()) + "> "
 }
+
+// AwaitInput marks the process as blocked on an interactive stdin read until
+// the returned func is called. Terminal reads ignore context cancellation, so
+// the interrupt handler checks InputPending to know it must exit on its own.
+func AwaitInput() (done func()) {
+	pendingInput.Add(1)
+	return func() { pendingInput.Add(-1) }
+}
+
+// InputPending reports whether a prompt is currently waiting on stdin.
+func InputPending() bool {
+	return pendingInput.Load() > 0
+}
@@ -173,6 +173,7 @@ func readKey(in *os.File) (int, error) {
 }
 
 func readByte(in *os.File) (byte, error) {
+	defer AwaitInput()()
 	var buf [1]byte
 	_, err := in.Read(buf[:])
 	if err != nil {